@@ -2,15 +2,34 @@ package gokart
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
+// CacheMode selects the Redis topology OpenCacheWithConfig connects to.
+type CacheMode int
+
+const (
+	// Standalone connects to a single Redis node via Addr or URL.
+	Standalone CacheMode = iota
+	// Sentinel connects through Redis Sentinel for automatic failover.
+	Sentinel
+	// Cluster connects to a Redis Cluster deployment.
+	Cluster
+)
+
 // CacheConfig configures Redis connection.
 type CacheConfig struct {
+	// Mode selects Standalone (default), Sentinel, or Cluster topology.
+	Mode CacheMode
+
 	// URL is the Redis connection string.
 	// Format: redis://:password@host:port/db or redis://host:port
 	URL string
@@ -19,10 +38,25 @@ type CacheConfig struct {
 	// Default: localhost:6379
 	Addr string
 
+	// SentinelAddrs lists Sentinel node addresses. Required when Mode is Sentinel.
+	SentinelAddrs []string
+
+	// MasterName is the Sentinel-monitored master group name. Required when Mode is Sentinel.
+	MasterName string
+
+	// ClusterAddrs lists seed node addresses. Required when Mode is Cluster.
+	ClusterAddrs []string
+
+	// RouteByLatency routes cluster reads to the replica with the lowest latency.
+	RouteByLatency bool
+
+	// RouteRandomly routes cluster reads to a random replica.
+	RouteRandomly bool
+
 	// Password for Redis authentication.
 	Password string
 
-	// DB is the Redis database number.
+	// DB is the Redis database number. Ignored in Cluster mode.
 	// Default: 0
 	DB int
 
@@ -46,8 +80,41 @@ type CacheConfig struct {
 	// Default: 3 seconds
 	WriteTimeout time.Duration
 
-	// KeyPrefix is prepended to all keys.
+	// KeyPrefix is prepended to all keys. In Cluster mode, keys are
+	// hash-tagged (e.g. "{myapp}:setting") so prefixed keys always land
+	// on the same slot and avoid CROSSSLOT errors.
 	KeyPrefix string
+
+	// Codec serializes values for GetTyped/SetTyped. Default: JSONCodec.
+	Codec Codec
+
+	// Compressor, when set, compresses encoded values at or above
+	// CompressThreshold bytes.
+	Compressor Compressor
+
+	// CompressThreshold is the minimum encoded size, in bytes, before
+	// Compressor is applied. Ignored if Compressor is nil. Default: 0
+	// (compress everything once a Compressor is configured).
+	CompressThreshold int
+
+	// TLSConfig enables TLS on the connection when set (e.g. for a
+	// rediss:// URL or a managed Redis provider that requires TLS).
+	TLSConfig *tls.Config
+
+	// InsecureSkipVerify disables server certificate verification. Only
+	// takes effect when TLSConfig is nil; set TLSConfig.InsecureSkipVerify
+	// directly for more control. Never enable this in production.
+	InsecureSkipVerify bool
+}
+
+func (cfg CacheConfig) tlsConfig() *tls.Config {
+	if cfg.TLSConfig != nil {
+		return cfg.TLSConfig
+	}
+	if cfg.InsecureSkipVerify {
+		return &tls.Config{InsecureSkipVerify: true}
+	}
+	return nil
 }
 
 // DefaultCacheConfig returns production-ready defaults.
@@ -63,10 +130,16 @@ func DefaultCacheConfig() CacheConfig {
 	}
 }
 
-// Cache wraps Redis client with convenience methods.
+// Cache wraps a Redis client (standalone, Sentinel, or Cluster) with
+// convenience methods.
 type Cache struct {
-	client *redis.Client
-	prefix string
+	client  redis.UniversalClient
+	prefix  string
+	cluster bool
+
+	codec             Codec
+	compressor        Compressor
+	compressThreshold int
 }
 
 // OpenCache opens a Redis connection with default settings.
@@ -84,13 +157,44 @@ func OpenCache(ctx context.Context, addr string) (*Cache, error) {
 	return OpenCacheWithConfig(ctx, cfg)
 }
 
-// OpenCacheURL opens a Redis connection using a URL.
+// OpenCacheURL opens a Redis connection using a URL, picking the right
+// client type from the scheme:
+//
+//	redis://host:port             standalone
+//	rediss://host:port            standalone over TLS
+//	redis-cluster://host1,host2   cluster (seed nodes)
+//	redis-sentinel://host1,host2/mymaster   sentinel (path is the master name)
 //
 // Example:
 //
 //	cache, err := gokart.OpenCacheURL(ctx, "redis://:password@localhost:6379/0")
-func OpenCacheURL(ctx context.Context, url string) (*Cache, error) {
-	opt, err := redis.ParseURL(url)
+func OpenCacheURL(ctx context.Context, rawURL string) (*Cache, error) {
+	switch {
+	case strings.HasPrefix(rawURL, "redis-cluster://"):
+		cfg := DefaultCacheConfig()
+		cfg.Mode = Cluster
+		addrs, password, err := parseMultiHostURL(rawURL, "redis-cluster://")
+		if err != nil {
+			return nil, err
+		}
+		cfg.ClusterAddrs = addrs
+		cfg.Password = password
+		return OpenCacheWithConfig(ctx, cfg)
+
+	case strings.HasPrefix(rawURL, "redis-sentinel://"):
+		cfg := DefaultCacheConfig()
+		cfg.Mode = Sentinel
+		addrs, password, masterName, err := parseSentinelURL(rawURL)
+		if err != nil {
+			return nil, err
+		}
+		cfg.SentinelAddrs = addrs
+		cfg.Password = password
+		cfg.MasterName = masterName
+		return OpenCacheWithConfig(ctx, cfg)
+	}
+
+	opt, err := redis.ParseURL(rawURL)
 	if err != nil {
 		return nil, fmt.Errorf("invalid redis URL: %w", err)
 	}
@@ -105,6 +209,57 @@ func OpenCacheURL(ctx context.Context, url string) (*Cache, error) {
 	return &Cache{client: client}, nil
 }
 
+// parseMultiHostURL extracts the comma-separated host list and password
+// from a "scheme://[:password@]host1,host2,.../" style URL that
+// url.Parse can't handle directly (it doesn't expect commas in Host).
+func parseMultiHostURL(rawURL, scheme string) (addrs []string, password string, err error) {
+	rest := strings.TrimPrefix(rawURL, scheme)
+	rest = strings.TrimSuffix(rest, "/")
+
+	if at := strings.LastIndex(rest, "@"); at >= 0 {
+		userinfo := rest[:at]
+		rest = rest[at+1:]
+		if colon := strings.Index(userinfo, ":"); colon >= 0 {
+			password = userinfo[colon+1:]
+		}
+	}
+
+	addrs = splitCSV(rest)
+	if len(addrs) == 0 {
+		return nil, "", fmt.Errorf("invalid redis URL %q: no hosts found", rawURL)
+	}
+	return addrs, password, nil
+}
+
+// parseSentinelURL extracts the Sentinel addresses, password, and master
+// name from a "redis-sentinel://[:password@]host1,host2/mymaster" URL.
+func parseSentinelURL(rawURL string) (addrs []string, password, masterName string, err error) {
+	rest := strings.TrimPrefix(rawURL, "redis-sentinel://")
+
+	masterName = ""
+	if slash := strings.Index(rest, "/"); slash >= 0 {
+		masterName = rest[slash+1:]
+		rest = rest[:slash]
+	}
+	if masterName == "" {
+		return nil, "", "", fmt.Errorf("invalid redis-sentinel URL %q: missing /mymaster path", rawURL)
+	}
+
+	if at := strings.LastIndex(rest, "@"); at >= 0 {
+		userinfo := rest[:at]
+		rest = rest[at+1:]
+		if colon := strings.Index(userinfo, ":"); colon >= 0 {
+			password = userinfo[colon+1:]
+		}
+	}
+
+	addrs = splitCSV(rest)
+	if len(addrs) == 0 {
+		return nil, "", "", fmt.Errorf("invalid redis-sentinel URL %q: no hosts found", rawURL)
+	}
+	return addrs, password, masterName, nil
+}
+
 // OpenCacheWithConfig opens a Redis connection with custom settings.
 //
 // Example:
@@ -115,36 +270,144 @@ func OpenCacheURL(ctx context.Context, url string) (*Cache, error) {
 //	    KeyPrefix: "myapp:",
 //	})
 func OpenCacheWithConfig(ctx context.Context, cfg CacheConfig) (*Cache, error) {
-	if cfg.URL != "" {
+	if cfg.Mode == Standalone && cfg.URL != "" {
 		cache, err := OpenCacheURL(ctx, cfg.URL)
 		if err != nil {
 			return nil, err
 		}
 		cache.prefix = cfg.KeyPrefix
+		cache.codec = cfg.Codec
+		cache.compressor = cfg.Compressor
+		cache.compressThreshold = cfg.CompressThreshold
 		return cache, nil
 	}
 
-	client := redis.NewClient(&redis.Options{
-		Addr:         cfg.Addr,
-		Password:     cfg.Password,
-		DB:           cfg.DB,
-		PoolSize:     cfg.PoolSize,
-		MinIdleConns: cfg.MinIdleConns,
-		DialTimeout:  cfg.DialTimeout,
-		ReadTimeout:  cfg.ReadTimeout,
-		WriteTimeout: cfg.WriteTimeout,
-	})
+	var client redis.UniversalClient
+	switch cfg.Mode {
+	case Sentinel:
+		if len(cfg.SentinelAddrs) == 0 || cfg.MasterName == "" {
+			return nil, fmt.Errorf("sentinel mode requires SentinelAddrs and MasterName")
+		}
+		client = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:    cfg.MasterName,
+			SentinelAddrs: cfg.SentinelAddrs,
+			Password:      cfg.Password,
+			DB:            cfg.DB,
+			PoolSize:      cfg.PoolSize,
+			MinIdleConns:  cfg.MinIdleConns,
+			DialTimeout:   cfg.DialTimeout,
+			ReadTimeout:   cfg.ReadTimeout,
+			WriteTimeout:  cfg.WriteTimeout,
+			TLSConfig:     cfg.tlsConfig(),
+		})
+	case Cluster:
+		if len(cfg.ClusterAddrs) == 0 {
+			return nil, fmt.Errorf("cluster mode requires ClusterAddrs")
+		}
+		client = redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:          cfg.ClusterAddrs,
+			Password:       cfg.Password,
+			PoolSize:       cfg.PoolSize,
+			MinIdleConns:   cfg.MinIdleConns,
+			DialTimeout:    cfg.DialTimeout,
+			ReadTimeout:    cfg.ReadTimeout,
+			WriteTimeout:   cfg.WriteTimeout,
+			RouteByLatency: cfg.RouteByLatency,
+			RouteRandomly:  cfg.RouteRandomly,
+			TLSConfig:      cfg.tlsConfig(),
+		})
+	default:
+		client = redis.NewClient(&redis.Options{
+			Addr:         cfg.Addr,
+			Password:     cfg.Password,
+			DB:           cfg.DB,
+			PoolSize:     cfg.PoolSize,
+			MinIdleConns: cfg.MinIdleConns,
+			DialTimeout:  cfg.DialTimeout,
+			ReadTimeout:  cfg.ReadTimeout,
+			WriteTimeout: cfg.WriteTimeout,
+			TLSConfig:    cfg.tlsConfig(),
+		})
+	}
 
 	if err := client.Ping(ctx).Err(); err != nil {
 		client.Close()
 		return nil, fmt.Errorf("failed to ping redis: %w", err)
 	}
 
-	return &Cache{client: client, prefix: cfg.KeyPrefix}, nil
+	return &Cache{
+		client:            client,
+		prefix:            cfg.KeyPrefix,
+		cluster:           cfg.Mode == Cluster,
+		codec:             cfg.Codec,
+		compressor:        cfg.Compressor,
+		compressThreshold: cfg.CompressThreshold,
+	}, nil
+}
+
+// OpenCacheFromEnv opens a Redis connection configured entirely from
+// environment variables, matching the binding pattern used elsewhere in
+// the module: REDIS_MODE (standalone|sentinel|cluster), REDIS_URL,
+// REDIS_ADDR, REDIS_SENTINEL_ADDRS (comma-separated), REDIS_MASTER_NAME,
+// REDIS_CLUSTER_ADDRS (comma-separated), REDIS_PASSWORD, REDIS_DB, and
+// REDIS_KEY_PREFIX.
+//
+// Example:
+//
+//	// REDIS_MODE=cluster REDIS_CLUSTER_ADDRS=10.0.0.1:6379,10.0.0.2:6379
+//	cache, err := gokart.OpenCacheFromEnv(ctx)
+func OpenCacheFromEnv(ctx context.Context) (*Cache, error) {
+	cfg := DefaultCacheConfig()
+
+	switch strings.ToLower(os.Getenv("REDIS_MODE")) {
+	case "sentinel":
+		cfg.Mode = Sentinel
+		cfg.SentinelAddrs = splitCSV(os.Getenv("REDIS_SENTINEL_ADDRS"))
+		cfg.MasterName = os.Getenv("REDIS_MASTER_NAME")
+	case "cluster":
+		cfg.Mode = Cluster
+		cfg.ClusterAddrs = splitCSV(os.Getenv("REDIS_CLUSTER_ADDRS"))
+	default:
+		cfg.Mode = Standalone
+	}
+
+	if url := os.Getenv("REDIS_URL"); url != "" {
+		cfg.URL = url
+	}
+	if addr := os.Getenv("REDIS_ADDR"); addr != "" {
+		cfg.Addr = addr
+	}
+	if password := os.Getenv("REDIS_PASSWORD"); password != "" {
+		cfg.Password = password
+	}
+	if db := os.Getenv("REDIS_DB"); db != "" {
+		if n, err := strconv.Atoi(db); err == nil {
+			cfg.DB = n
+		}
+	}
+	cfg.KeyPrefix = os.Getenv("REDIS_KEY_PREFIX")
+
+	return OpenCacheWithConfig(ctx, cfg)
+}
+
+func splitCSV(s string) []string {
+	if s == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
 }
 
-// Client returns the underlying Redis client.
-func (c *Cache) Client() *redis.Client {
+// Client returns the underlying Redis client. In Cluster or Sentinel mode
+// this is a redis.UniversalClient backed by *redis.ClusterClient or
+// *redis.FailoverClient rather than *redis.Client.
+func (c *Cache) Client() redis.UniversalClient {
 	return c.client
 }
 
@@ -153,12 +416,19 @@ func (c *Cache) Close() error {
 	return c.client.Close()
 }
 
-// key prefixes the key if a prefix is configured.
+// key prefixes the key if a prefix is configured. In Cluster mode the
+// prefix is rendered as a hash tag ("{prefix}:key") so every prefixed key
+// lands on the same slot, keeping multi-key commands like Delete and
+// pipelined MGet/MSet CROSSSLOT-safe.
 func (c *Cache) key(k string) string {
-	if c.prefix != "" {
-		return c.prefix + k
+	if c.prefix == "" {
+		return k
+	}
+	if c.cluster {
+		tag := strings.TrimSuffix(c.prefix, ":")
+		return fmt.Sprintf("{%s}:%s", tag, k)
 	}
-	return k
+	return c.prefix + k
 }
 
 // Get retrieves a string value.
@@ -189,13 +459,26 @@ func (c *Cache) SetJSON(ctx context.Context, key string, value interface{}, ttl
 	return c.client.Set(ctx, c.key(key), data, ttl).Err()
 }
 
-// Delete removes a key.
+// Delete removes keys. In Cluster mode, keys are deleted via a pipeline
+// (redis.ClusterClient spreads a pipeline's commands across the owning
+// nodes automatically) so a multi-key delete isn't limited to keys that
+// share a hash slot, unlike a raw DEL with multiple keys.
 func (c *Cache) Delete(ctx context.Context, keys ...string) error {
 	prefixedKeys := make([]string, len(keys))
 	for i, k := range keys {
 		prefixedKeys[i] = c.key(k)
 	}
-	return c.client.Del(ctx, prefixedKeys...).Err()
+
+	if !c.cluster || len(prefixedKeys) <= 1 {
+		return c.client.Del(ctx, prefixedKeys...).Err()
+	}
+
+	pipe := c.client.Pipeline()
+	for _, k := range prefixedKeys {
+		pipe.Del(ctx, k)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
 }
 
 // Exists checks if a key exists.
@@ -229,6 +512,42 @@ func (c *Cache) SetNX(ctx context.Context, key string, value string, ttl time.Du
 	return c.client.SetNX(ctx, c.key(key), value, ttl).Result()
 }
 
+// MGet retrieves multiple string values in one round trip. In Cluster
+// mode the keys are pipelined per-slot (redis.ClusterClient spreads a
+// pipeline's commands across the owning nodes automatically), so keys
+// without a shared hash tag still work correctly, unlike a raw MGET.
+func (c *Cache) MGet(ctx context.Context, keys ...string) ([]string, error) {
+	pipe := c.client.Pipeline()
+	cmds := make([]*redis.StringCmd, len(keys))
+	for i, k := range keys {
+		cmds[i] = pipe.Get(ctx, c.key(k))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	values := make([]string, len(keys))
+	for i, cmd := range cmds {
+		v, err := cmd.Result()
+		if err != nil && err != redis.Nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// MSet stores multiple string values with a shared TTL in one round trip,
+// pipelined per-slot in Cluster mode.
+func (c *Cache) MSet(ctx context.Context, values map[string]string, ttl time.Duration) error {
+	pipe := c.client.Pipeline()
+	for k, v := range values {
+		pipe.Set(ctx, c.key(k), v, ttl)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
 // Remember gets a value or sets it using the provided function.
 //
 // Example:
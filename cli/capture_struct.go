@@ -0,0 +1,216 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/spf13/viper"
+)
+
+// ErrCaptureAborted is returned by CaptureStruct when the user aborts by
+// leaving the editor buffer empty, matching the `git commit` UX.
+var ErrCaptureAborted = errors.New("cli: capture aborted (empty file)")
+
+// CaptureStructOptions configures CaptureStruct.
+type CaptureStructOptions struct {
+	// Editor overrides $EDITOR (falling back to "vim"). Mainly useful
+	// in tests.
+	Editor string
+
+	// Retries caps how many times the editor re-opens after a parse or
+	// validation failure. Default: 3.
+	Retries int
+
+	// Diff, if set, is called with the pre- and post-edit values once
+	// CaptureStruct succeeds, so callers can print a lipgloss-styled
+	// diff using this package's style palette.
+	Diff func(before, after any)
+}
+
+func (o CaptureStructOptions) withDefaults() CaptureStructOptions {
+	if o.Editor == "" {
+		o.Editor = os.Getenv("EDITOR")
+		if o.Editor == "" {
+			o.Editor = "vim"
+		}
+	}
+	if o.Retries == 0 {
+		o.Retries = 3
+	}
+	return o
+}
+
+// CaptureStruct marshals initial into format ("yaml", "toml", or "json")
+// with `doc:"..."` tag comments, opens it in $EDITOR, then unmarshals
+// and validates (`validate:"..."` tags, go-playground/validator) the
+// result. On parse or validation failure, it re-opens the editor on the
+// same buffer with the error messages prepended as a comment block (for
+// "json", which has no comment syntax, the errors are printed to stderr
+// and the buffer is reopened unchanged), retrying up to opts.Retries
+// times. Leaving the file empty aborts with
+// ErrCaptureAborted, matching the `git commit` UX.
+//
+// Example:
+//
+//	type Release struct {
+//	    Version string `mapstructure:"version" doc:"semver to tag" validate:"required,semver"`
+//	    Notes   string `mapstructure:"notes" doc:"release notes"`
+//	}
+//
+//	rel, err := cli.CaptureStruct(Release{Version: "v1.0.0"}, "yaml", cli.CaptureStructOptions{
+//	    Diff: func(before, after any) { /* print a diff */ },
+//	})
+func CaptureStruct[T any](initial T, format string, opts CaptureStructOptions) (T, error) {
+	var zero T
+	opts = opts.withDefaults()
+
+	format = strings.ToLower(format)
+	if format == "" {
+		format = "yaml"
+	}
+
+	scaffold, err := marshalCaptureScaffold(initial, format)
+	if err != nil {
+		return zero, fmt.Errorf("marshal initial value: %w", err)
+	}
+
+	content := scaffold
+	var errHeader string
+
+	for attempt := 0; attempt <= opts.Retries; attempt++ {
+		buffer := content
+		if errHeader != "" {
+			buffer = append([]byte(errHeader), content...)
+		}
+
+		edited, err := editBuffer(opts.Editor, buffer, format)
+		if err != nil {
+			return zero, err
+		}
+		if strings.TrimSpace(edited) == "" {
+			return zero, ErrCaptureAborted
+		}
+		content = []byte(edited)
+
+		result, err := unmarshalCapture[T](content, format)
+		if err == nil {
+			if verr := validator.New().Struct(result); verr != nil {
+				err = verr
+			}
+		}
+		if err == nil {
+			if opts.Diff != nil {
+				opts.Diff(initial, result)
+			}
+			return result, nil
+		}
+
+		if format == "json" {
+			// JSON has no comment syntax, so the error can't be woven
+			// into the buffer the next edit shows; surface it on
+			// stderr instead and reopen the editor unchanged.
+			Error("%v", err)
+			continue
+		}
+		errHeader = formatCaptureErrorHeader(err)
+	}
+
+	return zero, fmt.Errorf("capture struct: exceeded %d retries, last error recorded in buffer", opts.Retries)
+}
+
+func marshalCaptureScaffold(v any, format string) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+
+	var buf bytes.Buffer
+	switch format {
+	case "toml":
+		if err := writeTOMLScaffold(&buf, rv); err != nil {
+			return nil, err
+		}
+	case "json":
+		if err := writeJSONScaffold(&buf, rv); err != nil {
+			return nil, err
+		}
+	default:
+		if err := writeYAMLScaffold(&buf, rv, 0); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func unmarshalCapture[T any](content []byte, format string) (T, error) {
+	var result T
+
+	v := viper.New()
+	v.SetConfigType(format)
+	if err := v.ReadConfig(bytes.NewReader(content)); err != nil {
+		return result, fmt.Errorf("parse %s: %w", format, err)
+	}
+	if err := v.Unmarshal(&result); err != nil {
+		return result, fmt.Errorf("unmarshal %s: %w", format, err)
+	}
+	return result, nil
+}
+
+// formatCaptureErrorHeader renders err as a "#"-prefixed comment block
+// (valid in both YAML and TOML) to prepend to the buffer on retry.
+func formatCaptureErrorHeader(err error) string {
+	var lines []string
+	lines = append(lines, "# Fix the error(s) below, or clear this file to abort:")
+	for _, line := range strings.Split(err.Error(), "\n") {
+		lines = append(lines, "# "+line)
+	}
+	lines = append(lines, "")
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// editBuffer writes content to a temp file with the given extension,
+// runs editor on it, and returns the resulting file content. It mirrors
+// CaptureInputWithEditor's temp-file handling without the
+// trailing-newline trim, since structured formats care about exact
+// content.
+func editBuffer(editor string, content []byte, extension string) (string, error) {
+	tmpfile, err := os.CreateTemp("", "gokart-capture-*."+extension)
+	if err != nil {
+		return "", fmt.Errorf("create temp file: %w", err)
+	}
+	if err := tmpfile.Chmod(0600); err != nil {
+		tmpfile.Close()
+		os.Remove(tmpfile.Name())
+		return "", fmt.Errorf("set temp file permissions: %w", err)
+	}
+	tmpPath := tmpfile.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmpfile.Write(content); err != nil {
+		tmpfile.Close()
+		return "", fmt.Errorf("write temp file: %w", err)
+	}
+	if err := tmpfile.Close(); err != nil {
+		return "", fmt.Errorf("close temp file: %w", err)
+	}
+
+	cmd := exec.Command(editor, tmpPath)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("run editor: %w", err)
+	}
+
+	edited, err := os.ReadFile(tmpPath)
+	if err != nil {
+		return "", fmt.Errorf("read edited content: %w", err)
+	}
+	return string(edited), nil
+}
@@ -0,0 +1,83 @@
+package cli_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotcommander/gokart/cli"
+)
+
+type captureTestConfig struct {
+	Name string `mapstructure:"name" doc:"service name" validate:"required"`
+	Port int    `mapstructure:"port" doc:"listen port" validate:"min=1"`
+}
+
+func TestCaptureStruct_RoundTripsUnchangedBuffer(t *testing.T) {
+	t.Parallel()
+
+	// 'true' leaves the scaffold file untouched, so the result should
+	// match the initial value.
+	result, err := cli.CaptureStruct(captureTestConfig{Name: "svc", Port: 8080}, "yaml", cli.CaptureStructOptions{
+		Editor: "true",
+	})
+	if err != nil {
+		t.Fatalf("CaptureStruct failed: %v", err)
+	}
+	if result.Name != "svc" || result.Port != 8080 {
+		t.Errorf("result = %+v, want Name=svc Port=8080", result)
+	}
+}
+
+func TestCaptureStruct_AbortsOnEmptyFile(t *testing.T) {
+	t.Parallel()
+
+	script := filepath.Join(t.TempDir(), "empty-file.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\n: > \"$1\"\n"), 0755); err != nil {
+		t.Fatalf("create script: %v", err)
+	}
+
+	_, err := cli.CaptureStruct(captureTestConfig{Name: "svc", Port: 8080}, "yaml", cli.CaptureStructOptions{
+		Editor: script,
+	})
+	if !errors.Is(err, cli.ErrCaptureAborted) {
+		t.Errorf("expected ErrCaptureAborted, got %v", err)
+	}
+}
+
+func TestCaptureStruct_RetriesUntilValid(t *testing.T) {
+	t.Parallel()
+
+	// First invocation leaves the invalid "name: \"\"" scaffold as-is
+	// (fails validation); second invocation fixes it by overwriting the
+	// file. The script tracks its own call count via a counter file.
+	counter := filepath.Join(t.TempDir(), "calls")
+	script := filepath.Join(t.TempDir(), "fix-second-time.sh")
+	scriptContent := `#!/bin/sh
+count_file="` + counter + `"
+count=0
+if [ -f "$count_file" ]; then
+  count=$(cat "$count_file")
+fi
+count=$((count + 1))
+echo "$count" > "$count_file"
+if [ "$count" -ge 2 ]; then
+  printf 'name: "svc"\nport: 8080\n' > "$1"
+fi
+`
+	if err := os.WriteFile(script, []byte(scriptContent), 0755); err != nil {
+		t.Fatalf("create script: %v", err)
+	}
+
+	result, err := cli.CaptureStruct(captureTestConfig{Name: "", Port: 8080}, "yaml", cli.CaptureStructOptions{
+		Editor:  script,
+		Retries: 3,
+	})
+	if err != nil {
+		t.Fatalf("CaptureStruct failed: %v", err)
+	}
+	if result.Name != "svc" || result.Port != 8080 {
+		t.Errorf("result = %+v, want Name=svc Port=8080", result)
+	}
+}
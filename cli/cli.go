@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync/atomic"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
@@ -21,6 +22,13 @@ type App struct {
 	configFile  string
 	configName  string
 	envPrefix   string
+
+	pluginDirs []string
+	plugins    []*pluginInfo
+
+	configSchema   any
+	configSnapshot atomic.Pointer[any]
+	configOnChange []func(old, new any)
 }
 
 // NewApp creates a new CLI application builder.
@@ -0,0 +1,328 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-playground/validator/v10"
+	"github.com/spf13/cobra"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// WithConfigSchema registers schema (typically a zero value or a value
+// pre-populated with defaults, e.g. `Config{Port: 8080}`) as the app's
+// config shape, and wires a hidden "config" command group ("config
+// init", "config validate", "config show") onto the app.
+//
+// schema's fields are read via their `mapstructure` tag (falling back to
+// `yaml`, then the lowercased field name) to match the key GenerateConfig
+// writes and ValidateConfig reads back via viper. A `doc:"..."` tag
+// documents the field in GenerateConfig's scaffold; a `validate:"..."`
+// tag (go-playground/validator syntax) is enforced by ValidateConfig.
+//
+// Example:
+//
+//	type Config struct {
+//	    Port int    `mapstructure:"port" doc:"HTTP listen port" validate:"required,min=1"`
+//	    Name string `mapstructure:"name" doc:"Service name" validate:"required"`
+//	}
+//
+//	app := cli.NewApp("myapp", "1.0.0").WithConfigSchema(Config{Port: 8080})
+func (a *App) WithConfigSchema(schema any) *App {
+	a.configSchema = schema
+	a.root.AddCommand(a.configGroupCommand())
+	return a
+}
+
+// GenerateConfig walks the schema registered with WithConfigSchema and
+// writes a scaffold populated with its field values (as defaults) to w,
+// in the given format ("yaml", "toml", or "json"). YAML and TOML output
+// include a comment above each field sourced from its `doc` tag; JSON
+// has no comment syntax, so those are omitted.
+//
+// Example:
+//
+//	f, _ := os.Create("config.yaml")
+//	defer f.Close()
+//	app.GenerateConfig(f, "yaml")
+func (a *App) GenerateConfig(w io.Writer, format string) error {
+	if a.configSchema == nil {
+		return fmt.Errorf("generate config: no schema registered (call WithConfigSchema first)")
+	}
+
+	v := reflect.ValueOf(a.configSchema)
+	for v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+
+	switch strings.ToLower(format) {
+	case "", "yaml", "yml":
+		return writeYAMLScaffold(w, v, 0)
+	case "toml":
+		return writeTOMLScaffold(w, v)
+	case "json":
+		return writeJSONScaffold(w, v)
+	default:
+		return fmt.Errorf("generate config: unsupported format %q", format)
+	}
+}
+
+func configFieldKey(f reflect.StructField) string {
+	if tag := f.Tag.Get("mapstructure"); tag != "" {
+		return strings.Split(tag, ",")[0]
+	}
+	if tag := f.Tag.Get("yaml"); tag != "" {
+		return strings.Split(tag, ",")[0]
+	}
+	return strings.ToLower(f.Name)
+}
+
+func writeYAMLScaffold(w io.Writer, v reflect.Value, indent int) error {
+	t := v.Type()
+	pad := strings.Repeat("  ", indent)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key := configFieldKey(field)
+		value := v.Field(i)
+
+		if doc := field.Tag.Get("doc"); doc != "" {
+			fmt.Fprintf(w, "%s# %s\n", pad, doc)
+		}
+
+		if value.Kind() == reflect.Struct && field.Type != timeType {
+			fmt.Fprintf(w, "%s%s:\n", pad, key)
+			if err := writeYAMLScaffold(w, value, indent+1); err != nil {
+				return err
+			}
+			continue
+		}
+
+		fmt.Fprintf(w, "%s%s: %s\n", pad, key, formatScalar(value))
+	}
+	return nil
+}
+
+func writeTOMLScaffold(w io.Writer, v reflect.Value) error {
+	t := v.Type()
+
+	var sections []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		value := v.Field(i)
+		if value.Kind() == reflect.Struct && field.Type != timeType {
+			sections = append(sections, field)
+			continue
+		}
+		if doc := field.Tag.Get("doc"); doc != "" {
+			fmt.Fprintf(w, "# %s\n", doc)
+		}
+		fmt.Fprintf(w, "%s = %s\n", configFieldKey(field), formatScalar(value))
+	}
+
+	for _, field := range sections {
+		fmt.Fprintf(w, "\n[%s]\n", configFieldKey(field))
+		sv := v.FieldByName(field.Name)
+		st := sv.Type()
+		for i := 0; i < st.NumField(); i++ {
+			sf := st.Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+			if doc := sf.Tag.Get("doc"); doc != "" {
+				fmt.Fprintf(w, "# %s\n", doc)
+			}
+			fmt.Fprintf(w, "%s = %s\n", configFieldKey(sf), formatScalar(sv.Field(i)))
+		}
+	}
+	return nil
+}
+
+func writeJSONScaffold(w io.Writer, v reflect.Value) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v.Interface())
+}
+
+func formatScalar(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return fmt.Sprintf("%q", v.String())
+	default:
+		return fmt.Sprintf("%v", v.Interface())
+	}
+}
+
+// ValidateConfig unmarshals the app's viper values into a fresh instance
+// of the schema registered with WithConfigSchema and runs
+// go-playground/validator over it, returning a single error joining one
+// message per failed `validate:"..."` tag, each prefixed with the
+// failing field's dotted path, rooted at the schema's type name (e.g.
+// "Config.Database.Port: min").
+func (a *App) ValidateConfig() error {
+	if a.configSchema == nil {
+		return fmt.Errorf("validate config: no schema registered (call WithConfigSchema first)")
+	}
+
+	target := reflect.New(reflect.TypeOf(a.configSchema))
+	if err := a.viper.Unmarshal(target.Interface()); err != nil {
+		return fmt.Errorf("unmarshal config: %w", err)
+	}
+
+	if err := validator.New().Struct(target.Interface()); err != nil {
+		var verrs validator.ValidationErrors
+		if asValidationErrors(err, &verrs) {
+			var msgs []string
+			for _, fe := range verrs {
+				msgs = append(msgs, fmt.Sprintf("%s: %s", fe.Namespace(), fe.Tag()))
+			}
+			return fmt.Errorf("config validation failed:\n  %s", strings.Join(msgs, "\n  "))
+		}
+		return fmt.Errorf("validate config: %w", err)
+	}
+
+	return nil
+}
+
+func asValidationErrors(err error, target *validator.ValidationErrors) bool {
+	verrs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return false
+	}
+	*target = verrs
+	return true
+}
+
+// WatchConfig arranges for the app's config file to be reloaded whenever
+// it changes on disk. Each reload unmarshals into a fresh schema
+// instance, validates it (a failed validation keeps the previous
+// snapshot and skips onChange), and atomically swaps the snapshot
+// returned by ConfigSnapshot so concurrent readers never observe a
+// torn value. onChange receives the old and new values as `any`;
+// type-assert (or use ConfigSnapshot[T]) to recover the concrete type.
+func (a *App) WatchConfig(onChange func(old, new any)) {
+	a.configOnChange = append(a.configOnChange, onChange)
+
+	a.viper.OnConfigChange(func(_ fsnotify.Event) {
+		a.reloadConfigSnapshot()
+	})
+	a.viper.WatchConfig()
+}
+
+func (a *App) reloadConfigSnapshot() {
+	if a.configSchema == nil {
+		return
+	}
+
+	target := reflect.New(reflect.TypeOf(a.configSchema))
+	if err := a.viper.Unmarshal(target.Interface()); err != nil {
+		Warning("config reload failed, keeping previous config: %v", err)
+		return
+	}
+	if err := validator.New().Struct(target.Interface()); err != nil {
+		Warning("config reload failed validation, keeping previous config: %v", err)
+		return
+	}
+
+	newVal := target.Elem().Interface()
+	var oldVal any
+	if old := a.configSnapshot.Load(); old != nil {
+		oldVal = *old
+	}
+	a.configSnapshot.Store(&newVal)
+
+	for _, fn := range a.configOnChange {
+		fn(oldVal, newVal)
+	}
+}
+
+// ConfigSnapshot returns the app's current live-reloaded config,
+// type-asserted to T. Go doesn't allow generic methods, so this is a
+// package-level function rather than a method on App, mirroring
+// gokart.LoadState[T]'s non-method generic shape.
+//
+// Example:
+//
+//	cfg := cli.ConfigSnapshot[Config](app)
+func ConfigSnapshot[T any](a *App) T {
+	if v := a.configSnapshot.Load(); v != nil {
+		if t, ok := (*v).(T); ok {
+			return t
+		}
+	}
+	var zero T
+	return zero
+}
+
+// configGroupCommand returns the hidden "config" command group wired up
+// by WithConfigSchema: "config init" writes a scaffold to --out (or
+// stdout), "config validate" runs ValidateConfig, and "config show"
+// prints the currently resolved values as JSON.
+func (a *App) configGroupCommand() *cobra.Command {
+	group := &cobra.Command{
+		Use:    "config",
+		Short:  "Manage this app's configuration file",
+		Hidden: true,
+	}
+
+	initCmd := Command("init", "Generate a config file scaffold", func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		out, _ := cmd.Flags().GetString("out")
+
+		var buf bytes.Buffer
+		if err := a.GenerateConfig(&buf, format); err != nil {
+			return err
+		}
+
+		if out == "" {
+			_, err := os.Stdout.Write(buf.Bytes())
+			return err
+		}
+		if err := os.WriteFile(out, buf.Bytes(), 0644); err != nil {
+			return fmt.Errorf("write config scaffold: %w", err)
+		}
+		Success("wrote %s", out)
+		return nil
+	})
+	initCmd.Flags().String("format", "yaml", "output format: yaml, toml, or json")
+	initCmd.Flags().String("out", "", "file to write (default: stdout)")
+	group.AddCommand(initCmd)
+
+	group.AddCommand(Command("validate", "Validate the resolved config against the registered schema", func(cmd *cobra.Command, args []string) error {
+		if err := a.ValidateConfig(); err != nil {
+			Error("%v", err)
+			return err
+		}
+		Success("config is valid")
+		return nil
+	}))
+
+	group.AddCommand(Command("show", "Print the currently resolved config as JSON", func(cmd *cobra.Command, args []string) error {
+		target := reflect.New(reflect.TypeOf(a.configSchema))
+		if err := a.viper.Unmarshal(target.Interface()); err != nil {
+			return fmt.Errorf("unmarshal config: %w", err)
+		}
+		out, err := json.MarshalIndent(target.Interface(), "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal config: %w", err)
+		}
+		fmt.Println(string(out))
+		return nil
+	}))
+
+	return group
+}
@@ -94,3 +94,109 @@ func CaptureInputWithEditor(editor, initial, extension string) (string, error) {
 
 	return result, nil
 }
+
+// CaptureInputOptions configures CaptureInputWithOptions.
+type CaptureInputOptions struct {
+	// Editor overrides $EDITOR (falling back to "vim").
+	Editor string
+
+	// Extension determines the temp file suffix, same as CaptureInput's
+	// extension parameter.
+	Extension string
+
+	// Retries caps how many times the editor re-opens after Validate
+	// fails. Default: 3.
+	Retries int
+
+	// Validate, if set, is run on the edited text (after StripComments,
+	// if enabled) before it's returned. A non-nil error reopens the
+	// editor on the previous buffer with the error prepended as a
+	// "#"-comment banner, git-commit-style.
+	Validate func(string) error
+
+	// StripComments removes lines whose first non-whitespace character
+	// is "#" before returning (and before Validate runs), git-commit-style,
+	// so callers can embed throwaway instructions in the initial buffer.
+	StripComments bool
+}
+
+func (o CaptureInputOptions) withDefaults() CaptureInputOptions {
+	if o.Editor == "" {
+		o.Editor = os.Getenv("EDITOR")
+		if o.Editor == "" {
+			o.Editor = "vim"
+		}
+	}
+	if o.Retries == 0 {
+		o.Retries = 3
+	}
+	return o
+}
+
+// CaptureInputWithOptions is CaptureInput with a validation loop and
+// optional comment stripping. Leaving the buffer empty aborts with
+// ErrCaptureAborted, matching the `git commit` UX. For capturing a Go
+// struct (rendered as commented YAML/JSON/TOML and round-tripped back),
+// use CaptureStruct instead.
+//
+// Example:
+//
+//	sql, err := cli.CaptureInputWithOptions("-- Enter a migration\n", cli.CaptureInputOptions{
+//	    Extension:     "sql",
+//	    StripComments: true,
+//	    Validate: func(s string) error {
+//	        if strings.TrimSpace(s) == "" {
+//	            return errors.New("migration must not be empty")
+//	        }
+//	        return nil
+//	    },
+//	})
+func CaptureInputWithOptions(initial string, opts CaptureInputOptions) (string, error) {
+	opts = opts.withDefaults()
+
+	content := initial
+	var errHeader string
+
+	for attempt := 0; attempt <= opts.Retries; attempt++ {
+		buffer := errHeader + content
+
+		edited, err := CaptureInputWithEditor(opts.Editor, buffer, opts.Extension)
+		if err != nil {
+			return "", err
+		}
+		if strings.TrimSpace(edited) == "" {
+			return "", ErrCaptureAborted
+		}
+		content = edited
+
+		result := content
+		if opts.StripComments {
+			result = stripHashComments(result)
+		}
+
+		if opts.Validate != nil {
+			if verr := opts.Validate(result); verr != nil {
+				errHeader = formatCaptureErrorHeader(verr)
+				continue
+			}
+		}
+
+		return result, nil
+	}
+
+	return "", fmt.Errorf("capture input: exceeded %d retries, last error recorded in buffer", opts.Retries)
+}
+
+// stripHashComments removes every line whose first non-whitespace
+// character is "#".
+func stripHashComments(s string) string {
+	lines := strings.Split(s, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
@@ -1,6 +1,7 @@
 package cli_test
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -98,3 +99,59 @@ func TestCaptureInput_FallsBackToVim(t *testing.T) {
 	// We can't actually test vim interaction in unit tests
 	t.Skip("requires interactive editor")
 }
+
+func TestCaptureInputWithOptions_StripsComments(t *testing.T) {
+	t.Parallel()
+
+	// 'true' leaves the buffer unchanged; StripComments should remove
+	// the leading comment line before Validate and the returned result.
+	result, err := cli.CaptureInputWithOptions("# a comment\nactual content", cli.CaptureInputOptions{
+		Editor:        "true",
+		Extension:     "txt",
+		StripComments: true,
+	})
+	if err != nil {
+		t.Fatalf("CaptureInputWithOptions failed: %v", err)
+	}
+	if result != "actual content" {
+		t.Errorf("expected 'actual content', got %q", result)
+	}
+}
+
+func TestCaptureInputWithOptions_FailsAfterRetriesExhausted(t *testing.T) {
+	t.Parallel()
+
+	// 'true' leaves the buffer unchanged every time, so Validate keeps
+	// failing until Retries is exhausted.
+	_, err := cli.CaptureInputWithOptions("bad", cli.CaptureInputOptions{
+		Editor:    "true",
+		Extension: "txt",
+		Retries:   2,
+		Validate: func(s string) error {
+			if s != "good" {
+				return errors.New("must be 'good'")
+			}
+			return nil
+		},
+	})
+	if err == nil {
+		t.Fatal("expected error after exhausting retries")
+	}
+}
+
+func TestCaptureInputWithOptions_AbortsOnEmptyFile(t *testing.T) {
+	t.Parallel()
+
+	script := filepath.Join(t.TempDir(), "empty.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\n> \"$1\"\n"), 0755); err != nil {
+		t.Fatalf("create script: %v", err)
+	}
+
+	_, err := cli.CaptureInputWithOptions("initial", cli.CaptureInputOptions{
+		Editor:    script,
+		Extension: "txt",
+	})
+	if !errors.Is(err, cli.ErrCaptureAborted) {
+		t.Errorf("expected ErrCaptureAborted, got %v", err)
+	}
+}
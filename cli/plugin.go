@@ -0,0 +1,281 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/dotcommander/gokart"
+	"github.com/spf13/cobra"
+)
+
+// pluginDescriptor is the JSON a plugin binary prints to stdout in
+// response to `<binary> __plugin_meta`, mirroring the kubectl/git plugin
+// model closely enough that a single binary can usually serve both.
+type pluginDescriptor struct {
+	Use   string       `json:"use"`
+	Short string       `json:"short"`
+	Long  string       `json:"long,omitempty"`
+	Flags []pluginFlag `json:"flags,omitempty"`
+}
+
+// pluginFlag records one of a plugin's flags as declared in its
+// descriptor. It is not bound onto the synthesized cobra.Command, since
+// the plugin parses its own flags (DisableFlagParsing is set on that
+// command), and `plugin list` does not print it.
+type pluginFlag struct {
+	Name      string `json:"name"`
+	Shorthand string `json:"shorthand,omitempty"`
+	Default   string `json:"default,omitempty"`
+	Usage     string `json:"usage,omitempty"`
+}
+
+// pluginInfo is a discovered plugin, ready to register as a subcommand.
+type pluginInfo struct {
+	Name       string // subcommand name, e.g. "deploy" for "myapp-deploy"
+	Path       string // absolute path to the plugin binary
+	Descriptor pluginDescriptor
+}
+
+// pluginCacheEntry memoizes a plugin's descriptor against the file
+// attributes it was read from, so AutoDiscoverPlugins doesn't exec every
+// candidate binary on every run.
+type pluginCacheEntry struct {
+	Key        string           `json:"key"` // path|mtime_unixnano|size
+	Descriptor pluginDescriptor `json:"descriptor"`
+}
+
+const pluginCacheFile = "plugins-cache.json"
+
+// WithPlugins adds directories AutoDiscoverPlugins searches for plugin
+// binaries, ahead of $PATH. Earlier dirs (and earlier calls to
+// WithPlugins) take priority when two directories both contain a plugin
+// for the same subcommand name.
+func (a *App) WithPlugins(dirs ...string) *App {
+	a.pluginDirs = append(a.pluginDirs, dirs...)
+	return a
+}
+
+// AutoDiscoverPlugins scans WithPlugins' directories and $PATH for
+// binaries named "<appname>-<sub>", registering each as a first-class
+// subcommand that forwards args, stdin/stdout/stderr, and the app's
+// resolved config to the plugin process — the kubectl/git plugin model.
+//
+// Built-in commands always win name collisions; among plugins, the
+// earlier directory wins and the later one is skipped with a warning.
+// A descriptor cache in the state dir (keyed by path, mtime, and size)
+// avoids re-executing `<binary> __plugin_meta` for unchanged binaries.
+//
+// Example:
+//
+//	app := cli.NewApp("myapp", "1.0.0").
+//	    WithPlugins("/usr/local/libexec/myapp").
+//	    AutoDiscoverPlugins()
+func (a *App) AutoDiscoverPlugins() *App {
+	a.root.AddCommand(a.pluginGroupCommand())
+
+	candidates := a.findPluginCandidates()
+	cache := a.loadPluginCache()
+	dirty := false
+	seen := make(map[string]string, len(candidates))
+	var discovered []*pluginInfo
+
+	for _, path := range candidates {
+		name := strings.TrimPrefix(filepath.Base(path), a.name+"-")
+		if name == filepath.Base(path) {
+			continue // doesn't match the "<appname>-<sub>" naming convention
+		}
+
+		if source, ok := seen[name]; ok {
+			Warning("plugin %q at %s shadowed by earlier plugin at %s", name, path, source)
+			continue
+		}
+		if a.hasBuiltinCommand(name) {
+			Warning("plugin %q at %s shadowed by a built-in command", name, path)
+			continue
+		}
+
+		descriptor, cached, err := a.resolvePluginDescriptor(path, cache)
+		if err != nil {
+			slog.Default().Warn("gokart: plugin discovery failed", "path", path, "error", err)
+			continue
+		}
+		if !cached {
+			dirty = true
+		}
+
+		seen[name] = path
+		discovered = append(discovered, &pluginInfo{Name: name, Path: path, Descriptor: descriptor})
+	}
+
+	if dirty {
+		a.savePluginCache(cache)
+	}
+
+	a.plugins = discovered
+	for _, p := range discovered {
+		a.root.AddCommand(a.pluginCommand(p))
+	}
+
+	return a
+}
+
+// findPluginCandidates lists executable files matching "<appname>-*" in
+// WithPlugins' directories (in order) followed by $PATH.
+func (a *App) findPluginCandidates() []string {
+	prefix := a.name + "-"
+
+	dirs := append([]string(nil), a.pluginDirs...)
+	if path := os.Getenv("PATH"); path != "" {
+		dirs = append(dirs, strings.Split(path, string(os.PathListSeparator))...)
+	}
+
+	var candidates []string
+	seenPaths := make(map[string]bool)
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if e.IsDir() || !strings.HasPrefix(e.Name(), prefix) {
+				continue
+			}
+			info, err := e.Info()
+			if err != nil || info.Mode()&0111 == 0 {
+				continue
+			}
+			full := filepath.Join(dir, e.Name())
+			if seenPaths[full] {
+				continue
+			}
+			seenPaths[full] = true
+			candidates = append(candidates, full)
+		}
+	}
+	return candidates
+}
+
+func (a *App) hasBuiltinCommand(name string) bool {
+	for _, cmd := range a.root.Commands() {
+		if cmd.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// resolvePluginDescriptor returns path's descriptor, from the cache if
+// its (path, mtime, size) key is still present, otherwise by executing
+// `path __plugin_meta` and updating cache in place.
+func (a *App) resolvePluginDescriptor(path string, cache map[string]pluginCacheEntry) (pluginDescriptor, bool, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return pluginDescriptor{}, false, fmt.Errorf("stat plugin: %w", err)
+	}
+	key := fmt.Sprintf("%s|%d|%d", path, info.ModTime().UnixNano(), info.Size())
+
+	if entry, ok := cache[key]; ok {
+		return entry.Descriptor, true, nil
+	}
+
+	out, err := exec.Command(path, "__plugin_meta").Output()
+	if err != nil {
+		return pluginDescriptor{}, false, fmt.Errorf("exec %s __plugin_meta: %w", path, err)
+	}
+
+	var descriptor pluginDescriptor
+	if err := json.Unmarshal(out, &descriptor); err != nil {
+		return pluginDescriptor{}, false, fmt.Errorf("parse plugin descriptor: %w", err)
+	}
+	if descriptor.Use == "" {
+		descriptor.Use = filepath.Base(path)
+	}
+
+	cache[key] = pluginCacheEntry{Key: key, Descriptor: descriptor}
+	return descriptor, false, nil
+}
+
+func (a *App) loadPluginCache() map[string]pluginCacheEntry {
+	cache, err := gokart.LoadState[map[string]pluginCacheEntry](a.name, pluginCacheFile)
+	if err != nil || cache == nil {
+		return make(map[string]pluginCacheEntry)
+	}
+	return cache
+}
+
+func (a *App) savePluginCache(cache map[string]pluginCacheEntry) {
+	if err := gokart.SaveState(a.name, pluginCacheFile, cache); err != nil {
+		slog.Default().Warn("gokart: failed to persist plugin descriptor cache", "error", err)
+	}
+}
+
+// pluginCommand synthesizes a cobra.Command that forwards to p's binary.
+// Flag parsing is disabled so the plugin's own flags (e.g. --help) reach
+// the plugin unmangled, matching how kubectl/git plugins behave.
+func (a *App) pluginCommand(p *pluginInfo) *cobra.Command {
+	return &cobra.Command{
+		Use:                p.Descriptor.Use,
+		Short:              p.Descriptor.Short,
+		Long:               p.Descriptor.Long,
+		DisableFlagParsing: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.execPlugin(p, args)
+		},
+	}
+}
+
+// execPlugin runs p's binary with args, forwarding the current
+// process's stdin/stdout/stderr and environment plus the app's
+// viper-resolved config as "<APPNAME>_CONFIG_JSON".
+func (a *App) execPlugin(p *pluginInfo, args []string) error {
+	cmd := exec.Command(p.Path, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), a.pluginConfigEnv())
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.ExitCode())
+		}
+		return fmt.Errorf("run plugin %s: %w", p.Name, err)
+	}
+	return nil
+}
+
+func (a *App) pluginConfigEnv() string {
+	envVar := strings.ToUpper(a.name) + "_CONFIG_JSON"
+	data, err := json.Marshal(a.viper.AllSettings())
+	if err != nil {
+		return envVar + "={}"
+	}
+	return envVar + "=" + string(data)
+}
+
+// pluginGroupCommand returns the "plugin" built-in command group,
+// registered before discovery runs so a plugin named "<appname>-plugin"
+// is shadowed by it like any other built-in.
+func (a *App) pluginGroupCommand() *cobra.Command {
+	group := Group("plugin", "Manage discovered plugin subcommands")
+	group.AddCommand(Command("list", "List discovered plugin subcommands", func(cmd *cobra.Command, args []string) error {
+		if len(a.plugins) == 0 {
+			Dim("No plugins discovered.")
+			return nil
+		}
+		for _, p := range a.plugins {
+			fmt.Printf("%s  %s\n", styleCommand.Render(p.Name), styleDim.Render(p.Path))
+			if p.Descriptor.Short != "" {
+				fmt.Printf("  %s\n", p.Descriptor.Short)
+			}
+		}
+		return nil
+	}))
+	return group
+}
@@ -7,6 +7,8 @@ import (
 	"os"
 	"sync"
 	"time"
+
+	"golang.org/x/term"
 )
 
 // Spinner shows an animated spinner with a message.
@@ -20,10 +22,22 @@ type Spinner struct {
 	cancel  context.CancelFunc
 	mu      sync.Mutex
 	running bool
+	tty     bool
 }
 
 var defaultFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
 
+// isTerminal reports whether w is a TTY, so spinners and progress bars
+// can fall back to plain line-oriented output when piped, captured in
+// CI logs, or pointed at a non-TTY writer.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	return term.IsTerminal(int(f.Fd()))
+}
+
 // NewSpinner creates a new spinner with a message.
 //
 // Example:
@@ -67,7 +81,9 @@ func (s *Spinner) Start() {
 }
 
 // StartWithContext begins the spinner animation with context cancellation.
-// The spinner stops automatically when the context is cancelled.
+// The spinner stops automatically when the context is cancelled. When the
+// writer is not a TTY, the animation degrades to a single "message…" line
+// instead of repeatedly overwriting the current line with carriage returns.
 func (s *Spinner) StartWithContext(ctx context.Context) {
 	s.mu.Lock()
 	if s.running {
@@ -77,8 +93,15 @@ func (s *Spinner) StartWithContext(ctx context.Context) {
 	s.running = true
 	s.done = make(chan struct{})
 	s.ctx, s.cancel = context.WithCancel(ctx)
+	s.tty = isTerminal(s.writer)
+	tty, msg := s.tty, s.message
 	s.mu.Unlock()
 
+	if !tty {
+		fmt.Fprintf(s.writer, "%s…\n", msg)
+		return
+	}
+
 	go func() {
 		i := 0
 		for {
@@ -107,7 +130,8 @@ func (s *Spinner) Update(message string) {
 	s.mu.Unlock()
 }
 
-// Stop stops the spinner and clears the line.
+// Stop stops the spinner and clears the line. On a non-TTY writer, where
+// no line was ever redrawn, it does nothing beyond halting the context.
 func (s *Spinner) Stop() {
 	s.mu.Lock()
 	if !s.running {
@@ -115,14 +139,16 @@ func (s *Spinner) Stop() {
 		return
 	}
 	s.running = false
+	tty := s.tty
 	close(s.done)
 	if s.cancel != nil {
 		s.cancel()
 	}
 	s.mu.Unlock()
 
-	// Clear the line
-	fmt.Fprintf(s.writer, "\r\033[K")
+	if tty {
+		fmt.Fprintf(s.writer, "\r\033[K")
+	}
 }
 
 // StopWithMessage stops and prints a final message.
@@ -167,11 +193,13 @@ func WithSpinner(message string, fn func() error) error {
 
 // Progress shows a simple progress indicator.
 type Progress struct {
-	total   int
-	current int
-	message string
-	writer  io.Writer
-	mu      sync.Mutex
+	total      int
+	current    int
+	message    string
+	writer     io.Writer
+	tty        bool
+	lastDecile int
+	mu         sync.Mutex
 }
 
 // NewProgress creates a progress indicator.
@@ -186,15 +214,18 @@ type Progress struct {
 //	p.Done()
 func NewProgress(message string, total int) *Progress {
 	return &Progress{
-		total:   total,
-		message: message,
-		writer:  os.Stdout,
+		total:      total,
+		message:    message,
+		writer:     os.Stdout,
+		tty:        isTerminal(os.Stdout),
+		lastDecile: -1,
 	}
 }
 
 // SetWriter sets the output writer.
 func (p *Progress) SetWriter(w io.Writer) *Progress {
 	p.writer = w
+	p.tty = isTerminal(w)
 	return p
 }
 
@@ -216,9 +247,21 @@ func (p *Progress) Set(current int) {
 	p.render()
 }
 
-// render draws the progress bar.
+// render draws the progress bar, or on a non-TTY writer emits one line
+// per 10% checkpoint reached instead of continuously redrawing.
 func (p *Progress) render() {
 	pct := float64(p.current) / float64(p.total) * 100
+
+	if !p.tty {
+		decile := int(pct) / 10
+		if decile == p.lastDecile && p.current != p.total {
+			return
+		}
+		p.lastDecile = decile
+		fmt.Fprintf(p.writer, "%s: %d/%d (%.0f%%)\n", p.message, p.current, p.total, pct)
+		return
+	}
+
 	barWidth := 30
 	filled := int(float64(barWidth) * float64(p.current) / float64(p.total))
 
@@ -241,5 +284,190 @@ func (p *Progress) Done() {
 
 	p.current = p.total
 	p.render()
-	fmt.Fprintln(p.writer)
+	if p.tty {
+		fmt.Fprintln(p.writer)
+	}
+}
+
+// groupEntry is one sub-spinner's state within a SpinnerGroup.
+type groupEntry struct {
+	message string
+	status  string // "", "done", or "failed"
+	err     error
+}
+
+// SpinnerGroup renders several concurrently-running spinners on adjacent
+// lines, for work like multi-region deploys where each task needs its
+// own status line. All rendering and sub-spinner updates go through a
+// single mutex so concurrent writes never interleave. On a non-TTY
+// writer each Add/Success/Error prints one plain line instead.
+//
+// Example:
+//
+//	g := cli.NewSpinnerGroup()
+//	g.Start()
+//	regions := []string{"us-east", "eu-west", "ap-south"}
+//	handles := make([]*cli.GroupHandle, len(regions))
+//	for i, r := range regions {
+//	    handles[i] = g.Add("deploying " + r)
+//	}
+//	var wg sync.WaitGroup
+//	for i, r := range regions {
+//	    wg.Add(1)
+//	    go func(i int, r string) {
+//	        defer wg.Done()
+//	        if err := deploy(r); err != nil {
+//	            handles[i].Error("deploying "+r, err)
+//	            return
+//	        }
+//	        handles[i].Success("deployed " + r)
+//	    }(i, r)
+//	}
+//	wg.Wait()
+//	g.Stop()
+type SpinnerGroup struct {
+	writer  io.Writer
+	delay   time.Duration
+	tty     bool
+	mu      sync.Mutex
+	entries []*groupEntry
+	frame   int
+	done    chan struct{}
+	started bool
+}
+
+// NewSpinnerGroup creates a SpinnerGroup writing to stdout.
+func NewSpinnerGroup() *SpinnerGroup {
+	return &SpinnerGroup{
+		writer: os.Stdout,
+		delay:  80 * time.Millisecond,
+		tty:    isTerminal(os.Stdout),
+		done:   make(chan struct{}),
+	}
+}
+
+// WithWriter sets the output writer.
+func (g *SpinnerGroup) WithWriter(w io.Writer) *SpinnerGroup {
+	g.writer = w
+	g.tty = isTerminal(w)
+	return g
+}
+
+// Add registers a new sub-spinner under message and returns a handle for
+// updating it independently via Update/Success/Error.
+func (g *SpinnerGroup) Add(message string) *GroupHandle {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	e := &groupEntry{message: message}
+	g.entries = append(g.entries, e)
+	if !g.tty {
+		fmt.Fprintf(g.writer, "%s…\n", message)
+	}
+	return &GroupHandle{group: g, entry: e}
+}
+
+// Start begins animating every registered sub-spinner. It is a no-op on
+// a non-TTY writer, since Add/Success/Error already print plain lines.
+func (g *SpinnerGroup) Start() {
+	g.mu.Lock()
+	if g.started || !g.tty {
+		g.mu.Unlock()
+		return
+	}
+	g.started = true
+	g.mu.Unlock()
+
+	go func() {
+		for {
+			select {
+			case <-g.done:
+				return
+			default:
+				g.render()
+				time.Sleep(g.delay)
+			}
+		}
+	}()
+}
+
+// Stop halts the animation goroutine, leaving the final status of every
+// sub-spinner on screen.
+func (g *SpinnerGroup) Stop() {
+	g.mu.Lock()
+	if !g.started {
+		g.mu.Unlock()
+		return
+	}
+	g.started = false
+	g.mu.Unlock()
+
+	close(g.done)
+	if g.tty {
+		g.render()
+	}
+}
+
+// render redraws every sub-spinner's line in place using cursor
+// save/restore so the group always repaints over the same block of
+// lines rather than scrolling the terminal.
+func (g *SpinnerGroup) render() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.frame++
+	fmt.Fprint(g.writer, "\033[s")
+	for _, e := range g.entries {
+		fmt.Fprint(g.writer, "\033[K")
+		switch e.status {
+		case "done":
+			fmt.Fprintf(g.writer, "✓ %s\n", e.message)
+		case "failed":
+			fmt.Fprintf(g.writer, "✗ %s: %v\n", e.message, e.err)
+		default:
+			fmt.Fprintf(g.writer, "%s %s\n", defaultFrames[g.frame%len(defaultFrames)], e.message)
+		}
+	}
+	fmt.Fprint(g.writer, "\033[u")
+}
+
+// GroupHandle is a handle to one sub-spinner registered with a
+// SpinnerGroup via Add.
+type GroupHandle struct {
+	group *SpinnerGroup
+	entry *groupEntry
+}
+
+// Update changes the sub-spinner's message.
+func (h *GroupHandle) Update(message string) {
+	h.group.mu.Lock()
+	h.entry.message = message
+	h.group.mu.Unlock()
+}
+
+// Success marks the sub-spinner done with a final message.
+func (h *GroupHandle) Success(message string) {
+	h.group.mu.Lock()
+	h.entry.message = message
+	h.entry.status = "done"
+	tty := h.group.tty
+	h.group.mu.Unlock()
+
+	if !tty {
+		fmt.Fprintf(h.group.writer, "✓ %s\n", message)
+	}
+}
+
+// Error marks the sub-spinner failed with a final message and error.
+func (h *GroupHandle) Error(message string, err error) {
+	h.group.mu.Lock()
+	h.entry.message = message
+	h.entry.status = "failed"
+	h.entry.err = err
+	tty := h.group.tty
+	h.group.mu.Unlock()
+
+	if !tty {
+		fmt.Fprintf(h.group.writer, "✗ %s: %v\n", message, err)
+	}
 }
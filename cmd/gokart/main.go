@@ -24,7 +24,9 @@ gokart new <name> [flags]
 
   --sqlite     SQLite database (modernc.org/sqlite)
   --postgres   PostgreSQL pool (pgx/v5)
-  --ai         OpenAI client (openai-go/v3)
+  --kv         Embedded KV store with HTTP/WebSocket sync (gokart/kv)
+  --ai                 LLM provider client (gokart.Provider)
+  --ai-provider <name> openai, anthropic, ollama, llamacpp, or grpc
   --flat       Single main.go (no internal/)
   --module     Custom module path`)
 
@@ -36,7 +38,10 @@ gokart new <name> [flags]
 			module, _ := cmd.Flags().GetString("module")
 			sqlite, _ := cmd.Flags().GetBool("sqlite")
 			postgres, _ := cmd.Flags().GetBool("postgres")
+			kvStore, _ := cmd.Flags().GetBool("kv")
 			ai, _ := cmd.Flags().GetBool("ai")
+			aiProvider, _ := cmd.Flags().GetString("ai-provider")
+			with, _ := cmd.Flags().GetString("with")
 
 			projectName := filepath.Base(projectArg)
 
@@ -52,8 +57,8 @@ gokart new <name> [flags]
 			}
 
 			if flat {
-				if sqlite || postgres || ai {
-					cli.Warning("--sqlite, --postgres, and --ai flags are ignored in flat mode")
+				if sqlite || postgres || kvStore || ai {
+					cli.Warning("--sqlite, --postgres, --kv, and --ai flags are ignored in flat mode")
 				}
 				cli.Info("Scaffolding flat project: %s", projectName)
 				if err := ScaffoldFlat(targetDir, projectName, module); err != nil {
@@ -61,7 +66,8 @@ gokart new <name> [flags]
 				}
 			} else {
 				cli.Info("Scaffolding structured project: %s", projectName)
-				if err := ScaffoldStructured(targetDir, projectName, module, sqlite, postgres, ai); err != nil {
+				services := ParseServices(with)
+				if err := ScaffoldStructured(targetDir, projectName, module, sqlite, postgres, kvStore, ai, aiProvider, services); err != nil {
 					return err
 				}
 			}
@@ -92,6 +98,9 @@ Flat mode creates a single main.go for quick scripts.`
   # With SQLite for local-first CLI
   gokart new mycli --sqlite
 
+  # With an embedded, reactive KV store instead
+  gokart new mycli --kv
+
   # Quick script (single main.go)
   gokart new script --flat
 
@@ -102,7 +111,10 @@ Flat mode creates a single main.go for quick scripts.`
 	newCmd.Flags().String("module", "", "Go module path (defaults to project name)")
 	newCmd.Flags().Bool("sqlite", false, "Include SQLite database wiring (modernc.org/sqlite)")
 	newCmd.Flags().Bool("postgres", false, "Include PostgreSQL connection pool (pgx/v5)")
-	newCmd.Flags().Bool("ai", false, "Include OpenAI client (openai-go/v3)")
+	newCmd.Flags().Bool("kv", false, "Include an embedded KV store with HTTP/WebSocket sync (gokart/kv)")
+	newCmd.Flags().Bool("ai", false, "Include an LLM provider client")
+	newCmd.Flags().String("ai-provider", "openai", "LLM provider for --ai: openai, anthropic, ollama, llamacpp, or grpc")
+	newCmd.Flags().String("with", "", "Comma-separated dev-stack services for docker-compose (redis,postgres,mysql,minio)")
 
 	app.AddCommand(newCmd)
 
@@ -9,13 +9,56 @@ import (
 //go:embed templates
 var templates embed.FS
 
+// Services is a bitset of optional backing services the docker-compose
+// dev-stack template should include.
+type Services uint8
+
+const (
+	ServiceRedis Services = 1 << iota
+	ServicePostgres
+	ServiceMySQL
+	ServiceMinIO
+)
+
+// Has reports whether s includes svc.
+func (s Services) Has(svc Services) bool {
+	return s&svc != 0
+}
+
+// ParseServices parses a comma-separated "--with" flag value (e.g.
+// "redis,postgres") into a Services bitset. Unknown names are ignored.
+func ParseServices(csv string) Services {
+	var services Services
+	for _, name := range strings.Split(csv, ",") {
+		switch strings.TrimSpace(strings.ToLower(name)) {
+		case "redis":
+			services |= ServiceRedis
+		case "postgres", "postgresql":
+			services |= ServicePostgres
+		case "mysql":
+			services |= ServiceMySQL
+		case "minio":
+			services |= ServiceMinIO
+		}
+	}
+	return services
+}
+
 // TemplateData holds variables for template substitution.
 type TemplateData struct {
-	Name      string
-	Module    string
-	GoVersion string
-	UseSQLite bool
-	UseAI     bool
+	Name        string
+	Module      string
+	GoVersion   string
+	UseSQLite   bool
+	UsePostgres bool
+	UseKV       bool
+	UseAI       bool
+	AIProvider  string
+
+	Services Services
+	UseRedis bool
+	UseMySQL bool
+	UseMinIO bool
 }
 
 // ScaffoldFlat creates a flat project structure with a single main.go.
@@ -29,14 +72,43 @@ func ScaffoldFlat(dir, name, module string) error {
 }
 
 // ScaffoldStructured creates a structured project with cmd/, internal/commands/, internal/actions/.
-func ScaffoldStructured(dir, name, module string, useSQLite, useAI bool) error {
+//
+// services additionally renders a docker-compose.yml dev stack, a
+// matching .env.example, and a scripts/test.sh under the project root,
+// plus a wire.go that conditionally opens the selected services.
+//
+// aiProvider selects which gokart.Provider the generated internal/actions
+// package wires up (openai, anthropic, ollama, llamacpp, grpc); ignored
+// unless useAI is true. Defaults to "openai" when empty.
+//
+// useKV scaffolds an embedded gokart/kv store (BoltDB-backed) mounted on
+// the chi router as both an importable Go API and an HTTP/WebSocket
+// pub-sub endpoint — a lighter alternative to --sqlite for reactive
+// local config/state.
+func ScaffoldStructured(dir, name, module string, useSQLite, usePostgres, useKV, useAI bool, aiProvider string, services Services) error {
+	if aiProvider == "" {
+		aiProvider = "openai"
+	}
+
 	data := TemplateData{
-		Name:      name,
-		Module:    module,
-		GoVersion: goVersion(),
-		UseSQLite: useSQLite,
-		UseAI:     useAI,
+		Name:        name,
+		Module:      module,
+		GoVersion:   goVersion(),
+		UseSQLite:   useSQLite,
+		UsePostgres: usePostgres,
+		UseKV:       useKV,
+		UseAI:       useAI,
+		AIProvider:  aiProvider,
+		Services:    services,
+		UseRedis:    services.Has(ServiceRedis),
+		UseMySQL:    services.Has(ServiceMySQL),
+		UseMinIO:    services.Has(ServiceMinIO),
+	}
+	// --postgres and --with postgres both mean "emit the Postgres service".
+	if usePostgres {
+		data.Services |= ServicePostgres
 	}
+	data.UsePostgres = data.Services.Has(ServicePostgres)
 	return Apply(templates, "templates/structured", dir, data)
 }
 
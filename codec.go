@@ -0,0 +1,308 @@
+package gokart
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// Codec marshals and unmarshals cache values. GetTyped/SetTyped use the
+// Cache's configured Codec instead of hard-coding encoding/json, so
+// callers can trade JSON's portability for msgpack/gob/protobuf's speed
+// and size without touching call sites.
+type Codec interface {
+	// Name identifies the codec in logs and the stored value header.
+	Name() string
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// Compressor wraps a Codec's output above CacheConfig.CompressThreshold
+// bytes, trading CPU for smaller payloads over the wire and in Redis
+// memory.
+type Compressor interface {
+	Name() string
+	Compress(data []byte) ([]byte, error)
+	Decompress(data []byte) ([]byte, error)
+}
+
+// codecID and compressorID are stored as a two-byte header (one byte
+// each) so a mixed
+// codec/compression deployment can be rolled out safely: readers decode
+// the header before choosing how to unmarshal, rather than assuming
+// every value was written with the currently configured Codec.
+type codecID byte
+
+const (
+	codecIDJSON codecID = iota
+	codecIDMsgpack
+	codecIDGob
+	codecIDProtobuf
+)
+
+type compressorID byte
+
+const (
+	compressorIDNone compressorID = iota
+	compressorIDGzip
+	compressorIDZstd
+)
+
+func codecIDOf(name string) codecID {
+	switch name {
+	case "msgpack":
+		return codecIDMsgpack
+	case "gob":
+		return codecIDGob
+	case "protobuf":
+		return codecIDProtobuf
+	default:
+		return codecIDJSON
+	}
+}
+
+func compressorIDOf(name string) compressorID {
+	switch name {
+	case "gzip":
+		return compressorIDGzip
+	case "zstd":
+		return compressorIDZstd
+	default:
+		return compressorIDNone
+	}
+}
+
+func codecForID(id codecID) Codec {
+	switch id {
+	case codecIDMsgpack:
+		return MsgpackCodec{}
+	case codecIDGob:
+		return GobCodec{}
+	case codecIDProtobuf:
+		return ProtobufCodec{}
+	default:
+		return JSONCodec{}
+	}
+}
+
+func compressorForID(id compressorID) Compressor {
+	switch id {
+	case compressorIDGzip:
+		return GzipCompressor{}
+	case compressorIDZstd:
+		return ZstdCompressor{}
+	default:
+		return nil
+	}
+}
+
+// JSONCodec is the default Codec, wrapping encoding/json.
+type JSONCodec struct{}
+
+func (JSONCodec) Name() string { return "json" }
+func (JSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+func (JSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// MsgpackCodec encodes values as MessagePack, typically 20-30% smaller
+// and faster to (un)marshal than JSON for the same Go struct.
+type MsgpackCodec struct{}
+
+func (MsgpackCodec) Name() string { return "msgpack" }
+func (MsgpackCodec) Marshal(v interface{}) ([]byte, error) {
+	return msgpack.Marshal(v)
+}
+func (MsgpackCodec) Unmarshal(data []byte, v interface{}) error {
+	return msgpack.Unmarshal(data, v)
+}
+
+// GobCodec encodes values with encoding/gob. Only useful between Go
+// processes sharing the same struct definitions; unlike JSON/msgpack it
+// is not a portable wire format.
+type GobCodec struct{}
+
+func (GobCodec) Name() string { return "gob" }
+func (GobCodec) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+func (GobCodec) Unmarshal(data []byte, v interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// ProtobufCodec encodes values using protocol buffers. v and the
+// destination passed to Unmarshal must implement proto.Message.
+type ProtobufCodec struct{}
+
+func (ProtobufCodec) Name() string { return "protobuf" }
+func (ProtobufCodec) Marshal(v interface{}) ([]byte, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("protobuf codec: %T does not implement proto.Message", v)
+	}
+	return proto.Marshal(msg)
+}
+func (ProtobufCodec) Unmarshal(data []byte, v interface{}) error {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("protobuf codec: %T does not implement proto.Message", v)
+	}
+	return proto.Unmarshal(data, msg)
+}
+
+// GzipCompressor compresses values with compress/gzip.
+type GzipCompressor struct{}
+
+func (GzipCompressor) Name() string { return "gzip" }
+func (GzipCompressor) Compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+func (GzipCompressor) Decompress(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// ZstdCompressor compresses values with zstd, generally both faster and
+// denser than gzip at the cost of a heavier dependency.
+type ZstdCompressor struct{}
+
+func (ZstdCompressor) Name() string { return "zstd" }
+func (ZstdCompressor) Compress(data []byte) ([]byte, error) {
+	enc, err := zstd.NewWriter(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer enc.Close()
+	return enc.EncodeAll(data, nil), nil
+}
+func (ZstdCompressor) Decompress(data []byte) ([]byte, error) {
+	dec, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer dec.Close()
+	return dec.DecodeAll(data, nil)
+}
+
+// encodeTyped marshals v with c's configured codec, compresses it if its
+// size meets or exceeds c.compressThreshold, and prepends a 2-byte header identifying
+// the codec and compression used so GetTyped can decode it correctly
+// even if the Cache's configuration changes later.
+func (c *Cache) encodeTyped(v interface{}) ([]byte, error) {
+	codec := c.codec
+	if codec == nil {
+		codec = JSONCodec{}
+	}
+
+	data, err := codec.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("marshal with %s codec: %w", codec.Name(), err)
+	}
+
+	cID := codecIDOf(codec.Name())
+
+	compID := compressorIDNone
+	if c.compressor != nil && len(data) >= c.compressThreshold {
+		compressed, err := c.compressor.Compress(data)
+		if err != nil {
+			return nil, fmt.Errorf("compress with %s: %w", c.compressor.Name(), err)
+		}
+		data = compressed
+		compID = compressorIDOf(c.compressor.Name())
+	}
+
+	out := make([]byte, 2+len(data))
+	out[0] = byte(cID)
+	out[1] = byte(compID)
+	copy(out[2:], data)
+	return out, nil
+}
+
+// decodeTyped reverses encodeTyped, reading the codec/compression header
+// to decode independently of the Cache's current configuration.
+func decodeTyped(data []byte, v interface{}) error {
+	if len(data) < 2 {
+		return fmt.Errorf("decode typed value: truncated header")
+	}
+
+	payload := data[2:]
+	if compID := compressorID(data[1]); compID != compressorIDNone {
+		comp := compressorForID(compID)
+		if comp == nil {
+			return fmt.Errorf("decode typed value: unknown compressor id %d", compID)
+		}
+		decompressed, err := comp.Decompress(payload)
+		if err != nil {
+			return fmt.Errorf("decompress with %s: %w", comp.Name(), err)
+		}
+		payload = decompressed
+	}
+
+	codec := codecForID(codecID(data[0]))
+	if err := codec.Unmarshal(payload, v); err != nil {
+		return fmt.Errorf("unmarshal with %s codec: %w", codec.Name(), err)
+	}
+	return nil
+}
+
+// GetTyped retrieves and decodes a value stored by SetTyped, using the
+// codec/compression identified by the value's stored header.
+//
+// Example:
+//
+//	user, err := gokart.GetTyped[User](ctx, cache, "user:123")
+func GetTyped[T any](ctx context.Context, c *Cache, key string) (T, error) {
+	var zero T
+
+	raw, err := c.client.Get(ctx, c.key(key)).Bytes()
+	if err != nil {
+		return zero, err
+	}
+
+	var v T
+	if err := decodeTyped(raw, &v); err != nil {
+		return zero, err
+	}
+	return v, nil
+}
+
+// SetTyped encodes value with c's configured Codec (and Compressor, if
+// the encoded size meets CompressThreshold) and stores it with ttl.
+//
+// Example:
+//
+//	err := gokart.SetTyped(ctx, cache, "user:123", user, time.Hour)
+func SetTyped[T any](ctx context.Context, c *Cache, key string, value T, ttl time.Duration) error {
+	data, err := c.encodeTyped(value)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, c.key(key), data, ttl).Err()
+}
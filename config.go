@@ -0,0 +1,102 @@
+package gokart
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/viper"
+)
+
+// LoadConfig loads a typed config from the first path in paths that
+// exists, binding environment variables automatically (a key like
+// "database.host" maps to DATABASE_HOST).
+//
+// Example:
+//
+//	cfg, err := gokart.LoadConfig[Config]("config.yaml", "config.json")
+func LoadConfig[T any](paths ...string) (T, error) {
+	var zero T
+	return LoadConfigWithDefaults(zero, paths...)
+}
+
+// LoadConfigWithDefaults loads a typed config the same way as LoadConfig,
+// but seeds viper with defaults first so any value missing from the file
+// and not overridden by the environment falls back to defaults.
+//
+// Example:
+//
+//	cfg, err := gokart.LoadConfigWithDefaults(Config{Port: 3000}, "config.yaml")
+func LoadConfigWithDefaults[T any](defaults T, paths ...string) (T, error) {
+	var result T
+
+	v, err := newConfigViper(defaults)
+	if err != nil {
+		return result, err
+	}
+
+	path := firstExistingPath(paths)
+	if path == "" {
+		return result, fmt.Errorf("load config: none of %v exist", paths)
+	}
+
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return result, fmt.Errorf("read config %s: %w", path, err)
+	}
+
+	if err := v.Unmarshal(&result); err != nil {
+		return result, fmt.Errorf("unmarshal config: %w", err)
+	}
+
+	return result, nil
+}
+
+// newConfigViper builds a viper instance seeded with defaults and
+// automatic environment binding, ready to have a config file read into it.
+func newConfigViper[T any](defaults T) (*viper.Viper, error) {
+	v := viper.New()
+
+	defaultsMap := make(map[string]any)
+	if err := mapstructure.Decode(defaults, &defaultsMap); err != nil {
+		return nil, fmt.Errorf("flatten config defaults: %w", err)
+	}
+	for key, value := range flatten("", defaultsMap) {
+		v.SetDefault(key, value)
+	}
+
+	v.AutomaticEnv()
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
+
+	return v, nil
+}
+
+// flatten turns a nested map into dot-separated keys so viper.SetDefault
+// sees "database.host" rather than a raw map value.
+func flatten(prefix string, m map[string]any) map[string]any {
+	out := make(map[string]any)
+	for k, v := range m {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if nested, ok := v.(map[string]any); ok {
+			for nk, nv := range flatten(key, nested) {
+				out[nk] = nv
+			}
+			continue
+		}
+		out[key] = v
+	}
+	return out
+}
+
+func firstExistingPath(paths []string) string {
+	for _, p := range paths {
+		if _, err := os.Stat(p); err == nil {
+			return p
+		}
+	}
+	return ""
+}
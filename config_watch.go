@@ -0,0 +1,204 @@
+package gokart
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// debounceWindow coalesces bursts of filesystem events (editors like vim
+// write + rename several times for one save) into a single reload.
+const debounceWindow = 200 * time.Millisecond
+
+// ConfigHandle holds a live-reloading config value. Get returns an atomic
+// snapshot that is safe to read concurrently with reloads.
+type ConfigHandle[T any] struct {
+	value atomic.Pointer[T]
+
+	mu           sync.Mutex
+	subs         []func(old, new T)
+	validate     func(T) error
+	defaultValue T
+
+	paths   []string
+	path    string
+	watcher *fsnotify.Watcher
+	sighup  chan os.Signal
+	done    chan struct{}
+}
+
+// WatchConfig loads a typed config and keeps it live-reloaded as the
+// backing file changes.
+//
+// Example:
+//
+//	handle, err := gokart.WatchConfig[Config]("config.yaml")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer handle.Close()
+//
+//	handle.OnChange(func(old, new Config) {
+//	    log.Printf("log level changed: %s -> %s", old.LogLevel, new.LogLevel)
+//	})
+func WatchConfig[T any](paths ...string) (*ConfigHandle[T], error) {
+	var zero T
+	return WatchConfigWithDefaults(zero, paths...)
+}
+
+// WatchConfigWithDefaults is WatchConfig with fallback default values,
+// mirroring LoadConfigWithDefaults.
+func WatchConfigWithDefaults[T any](defaults T, paths ...string) (*ConfigHandle[T], error) {
+	initial, err := LoadConfigWithDefaults(defaults, paths...)
+	if err != nil {
+		return nil, err
+	}
+	path := firstExistingPath(paths)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("create config watcher: %w", err)
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("watch config dir: %w", err)
+	}
+
+	h := &ConfigHandle[T]{
+		paths:   paths,
+		path:    path,
+		watcher: watcher,
+		sighup:  make(chan os.Signal, 1),
+		done:    make(chan struct{}),
+	}
+	h.value.Store(&initial)
+	h.defaults(defaults)
+
+	signal.Notify(h.sighup, syscall.SIGHUP)
+
+	go h.run()
+
+	return h, nil
+}
+
+// defaults stashes the caller's defaults so reload can reapply them; kept
+// as a closure-free field via a generic value copy.
+func (h *ConfigHandle[T]) defaults(defaults T) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.defaultValue = defaults
+}
+
+// Get returns the current config snapshot.
+func (h *ConfigHandle[T]) Get() T {
+	return *h.value.Load()
+}
+
+// OnChange registers a callback invoked after every successful reload.
+func (h *ConfigHandle[T]) OnChange(fn func(old, new T)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subs = append(h.subs, fn)
+}
+
+// WithValidate installs a validation hook. If it returns an error on
+// reload, the new value is discarded and the app keeps running on the
+// last good config.
+func (h *ConfigHandle[T]) WithValidate(fn func(T) error) *ConfigHandle[T] {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.validate = fn
+	return h
+}
+
+// Close stops the watcher goroutine.
+func (h *ConfigHandle[T]) Close() error {
+	close(h.done)
+	signal.Stop(h.sighup)
+	return h.watcher.Close()
+}
+
+func (h *ConfigHandle[T]) run() {
+	var debounce *time.Timer
+	reload := make(chan struct{}, 1)
+
+	trigger := func() {
+		if debounce != nil {
+			debounce.Stop()
+		}
+		debounce = time.AfterFunc(debounceWindow, func() {
+			select {
+			case reload <- struct{}{}:
+			default:
+			}
+		})
+	}
+
+	for {
+		select {
+		case <-h.done:
+			return
+
+		case event, ok := <-h.watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != filepath.Clean(h.path) {
+				continue
+			}
+			// Editors that rename the file away re-add it under the
+			// same path; re-register the watch on the containing dir
+			// to keep surviving renames/removes.
+			if event.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+				_ = h.watcher.Add(filepath.Dir(h.path))
+			}
+			trigger()
+
+		case <-h.sighup:
+			trigger()
+
+		case <-reload:
+			h.reload()
+
+		case _, ok := <-h.watcher.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+func (h *ConfigHandle[T]) reload() {
+	h.mu.Lock()
+	validate := h.validate
+	defaultValue := h.defaultValue
+	subs := append([]func(old, new T){}, h.subs...)
+	h.mu.Unlock()
+
+	newValue, err := LoadConfigWithDefaults(defaultValue, h.paths...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gokart: config reload failed, keeping previous config: %v\n", err)
+		return
+	}
+
+	if validate != nil {
+		if err := validate(newValue); err != nil {
+			fmt.Fprintf(os.Stderr, "gokart: config reload rejected by validator, keeping previous config: %v\n", err)
+			return
+		}
+	}
+
+	old := *h.value.Load()
+	h.value.Store(&newValue)
+
+	for _, fn := range subs {
+		fn(old, newValue)
+	}
+}
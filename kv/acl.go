@@ -0,0 +1,39 @@
+package kv
+
+import "context"
+
+// Op identifies the operation an ACLFunc is being asked to allow.
+type Op int
+
+const (
+	OpGet Op = iota
+	OpSet
+	OpDelete
+)
+
+func (op Op) String() string {
+	switch op {
+	case OpGet:
+		return "get"
+	case OpSet:
+		return "set"
+	case OpDelete:
+		return "delete"
+	default:
+		return "unknown"
+	}
+}
+
+// ACLFunc authorizes a single key access. Returning a non-nil error
+// denies the operation, and that error is surfaced to the caller (or, for
+// WebSocket clients, sent back as an error frame).
+//
+// Example:
+//
+//	cfg.ACL = func(ctx context.Context, op kv.Op, key string) error {
+//	    if op != kv.OpGet && strings.HasPrefix(key, "readonly/") {
+//	        return fmt.Errorf("key %q is read-only", key)
+//	    }
+//	    return nil
+//	}
+type ACLFunc func(ctx context.Context, op Op, key string) error
@@ -0,0 +1,6 @@
+package kv
+
+import "errors"
+
+// ErrNotFound is returned by Get when the key doesn't exist.
+var ErrNotFound = errors.New("kv: key not found")
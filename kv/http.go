@@ -0,0 +1,202 @@
+package kv
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
+)
+
+// Mount wires store's REST and WebSocket endpoints onto r under prefix
+// (e.g. "/kv"):
+//
+//	GET    {prefix}/keys?prefix=session/   list keys (and values) under a prefix
+//	GET    {prefix}/{key}                  get a single value
+//	PUT    {prefix}/{key}                  set a value (request body is the raw value)
+//	DELETE {prefix}/{key}                  delete a value
+//	GET    {prefix}/ws                      WebSocket pub-sub, kilovolt-style JSON frames
+//
+// Example:
+//
+//	r := chi.NewRouter()
+//	kv.Mount(r, store, "/kv")
+func Mount(r chi.Router, store *Store, prefix string) {
+	r.Route(prefix, func(r chi.Router) {
+		r.Get("/keys", store.handleList)
+		r.Get("/ws", store.handleWS)
+		r.Get("/{key}", store.handleGet)
+		r.Put("/{key}", store.handleSet)
+		r.Delete("/{key}", store.handleDelete)
+	})
+}
+
+func (s *Store) handleGet(w http.ResponseWriter, r *http.Request) {
+	value, err := s.Get(r.Context(), chi.URLParam(r, "key"))
+	if errors.Is(err, ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	w.Write(value)
+}
+
+func (s *Store) handleSet(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	value, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := s.Set(r.Context(), chi.URLParam(r, "key"), value); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Store) handleDelete(w http.ResponseWriter, r *http.Request) {
+	if err := s.Delete(r.Context(), chi.URLParam(r, "key")); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Store) handleList(w http.ResponseWriter, r *http.Request) {
+	results, err := s.Prefix(r.Context(), r.URL.Query().Get("prefix"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(results)
+}
+
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  4096,
+	WriteBufferSize: 4096,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// wsRequest is a client->server frame. Type is one of "get", "set",
+// "delete", "subscribe", "unsubscribe".
+type wsRequest struct {
+	Type   string `json:"type"`
+	ID     string `json:"id,omitempty"`
+	Key    string `json:"key,omitempty"`
+	Prefix string `json:"prefix,omitempty"`
+	Value  []byte `json:"value,omitempty"`
+}
+
+// wsResponse is a server->client frame: a reply to a request (matched by
+// ID) or an unsolicited "event" pushed by a subscription.
+type wsResponse struct {
+	Type    string `json:"type"`
+	ID      string `json:"id,omitempty"`
+	Key     string `json:"key,omitempty"`
+	Value   []byte `json:"value,omitempty"`
+	Deleted bool   `json:"deleted,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// handleWS upgrades the connection and serves the kilovolt-style pub-sub
+// protocol until the client disconnects.
+func (s *Store) handleWS(w http.ResponseWriter, r *http.Request) {
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	ctx := r.Context()
+	subs := make(map[string]*Subscription)
+	defer func() {
+		for _, sub := range subs {
+			sub.Close()
+		}
+	}()
+
+	out := make(chan wsResponse, subscriberBuffer)
+	done := make(chan struct{})
+	defer close(done)
+	go s.writeWSResponses(conn, out, done)
+
+	for {
+		var req wsRequest
+		if err := conn.ReadJSON(&req); err != nil {
+			return
+		}
+
+		switch req.Type {
+		case "get":
+			value, err := s.Get(ctx, req.Key)
+			out <- replyFor(req, value, err)
+		case "set":
+			err := s.Set(ctx, req.Key, req.Value)
+			out <- replyFor(req, nil, err)
+		case "delete":
+			err := s.Delete(ctx, req.Key)
+			out <- replyFor(req, nil, err)
+		case "subscribe":
+			sub := s.Subscribe(req.Prefix)
+			subs[req.ID] = sub
+			go forwardEvents(sub, out, done)
+			out <- wsResponse{Type: "ok", ID: req.ID}
+		case "unsubscribe":
+			sub, ok := subs[req.ID]
+			if !ok {
+				out <- wsResponse{Type: "error", ID: req.ID, Error: "unknown subscription id"}
+				continue
+			}
+			sub.Close()
+			delete(subs, req.ID)
+			out <- wsResponse{Type: "ok", ID: req.ID}
+		default:
+			out <- wsResponse{Type: "error", ID: req.ID, Error: "unknown request type: " + req.Type}
+		}
+	}
+}
+
+func replyFor(req wsRequest, value []byte, err error) wsResponse {
+	if err != nil {
+		return wsResponse{Type: "error", ID: req.ID, Key: req.Key, Error: err.Error()}
+	}
+	return wsResponse{Type: "value", ID: req.ID, Key: req.Key, Value: value}
+}
+
+func forwardEvents(sub *Subscription, out chan<- wsResponse, done <-chan struct{}) {
+	for {
+		select {
+		case event, ok := <-sub.C():
+			if !ok {
+				return
+			}
+			select {
+			case out <- wsResponse{Type: "event", Key: event.Key, Value: event.Value, Deleted: event.Deleted}:
+			case <-done:
+				return
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+func (s *Store) writeWSResponses(conn *websocket.Conn, out <-chan wsResponse, done <-chan struct{}) {
+	for {
+		select {
+		case resp := <-out:
+			if err := conn.WriteJSON(resp); err != nil {
+				return
+			}
+		case <-done:
+			return
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// Package kv provides an embedded, reactive key-value store for
+// local-first CLIs and services that want a lighter alternative to Redis
+// for config/state that only the process itself (or a few local clients)
+// needs to see.
+//
+// Store wraps go.etcd.io/bbolt (pure Go, single-file, like
+// modernc.org/sqlite's zero-CGO tradeoff) and layers pub-sub on top:
+// every Set/Delete fans out to in-process Subscribers, and — via
+// Mount — to remote WebSocket clients speaking a small JSON
+// protocol modeled on strimertul's kilovolt.
+package kv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+// Config configures Store's backing bbolt database.
+type Config struct {
+	// Path is the database file path.
+	Path string
+
+	// Bucket is the bbolt bucket holding all keys. Default: "kv".
+	Bucket string
+
+	// OpenTimeout bounds how long Open waits to acquire the file lock
+	// bbolt takes on the database file. Default: 1 second.
+	OpenTimeout time.Duration
+
+	// ACL, if set, is consulted before every Get/Set/Delete/Prefix.
+	ACL ACLFunc
+}
+
+// DefaultConfig returns sensible defaults for path.
+func DefaultConfig(path string) Config {
+	return Config{
+		Path:        path,
+		Bucket:      "kv",
+		OpenTimeout: time.Second,
+	}
+}
+
+// Store is an embedded key-value store with pub-sub change notifications.
+type Store struct {
+	db     *bbolt.DB
+	bucket []byte
+	acl    ACLFunc
+
+	bus *bus
+}
+
+// Open opens a Store at path with default settings.
+//
+// Example:
+//
+//	store, err := kv.Open("app.kv")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer store.Close()
+func Open(path string) (*Store, error) {
+	return OpenWithConfig(DefaultConfig(path))
+}
+
+// OpenWithConfig opens a Store with custom settings.
+func OpenWithConfig(cfg Config) (*Store, error) {
+	if cfg.Bucket == "" {
+		cfg.Bucket = "kv"
+	}
+	if cfg.OpenTimeout == 0 {
+		cfg.OpenTimeout = time.Second
+	}
+
+	db, err := bbolt.Open(cfg.Path, 0600, &bbolt.Options{Timeout: cfg.OpenTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("kv: open %s: %w", cfg.Path, err)
+	}
+
+	bucket := []byte(cfg.Bucket)
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("kv: create bucket %s: %w", cfg.Bucket, err)
+	}
+
+	return &Store{
+		db:     db,
+		bucket: bucket,
+		acl:    cfg.ACL,
+		bus:    newBus(),
+	}, nil
+}
+
+// Close releases the underlying database file.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Get returns key's value, or ErrNotFound if it doesn't exist.
+func (s *Store) Get(ctx context.Context, key string) ([]byte, error) {
+	if err := s.checkACL(ctx, OpGet, key); err != nil {
+		return nil, err
+	}
+
+	var value []byte
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket(s.bucket).Get([]byte(key))
+		if v == nil {
+			return ErrNotFound
+		}
+		value = append([]byte(nil), v...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Set stores value under key and notifies subscribers.
+func (s *Store) Set(ctx context.Context, key string, value []byte) error {
+	if err := s.checkACL(ctx, OpSet, key); err != nil {
+		return err
+	}
+
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(s.bucket).Put([]byte(key), value)
+	})
+	if err != nil {
+		return fmt.Errorf("kv: set %s: %w", key, err)
+	}
+
+	s.bus.publish(Event{Key: key, Value: value})
+	return nil
+}
+
+// Delete removes key and notifies subscribers. Deleting a key that
+// doesn't exist is not an error.
+func (s *Store) Delete(ctx context.Context, key string) error {
+	if err := s.checkACL(ctx, OpDelete, key); err != nil {
+		return err
+	}
+
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(s.bucket).Delete([]byte(key))
+	})
+	if err != nil {
+		return fmt.Errorf("kv: delete %s: %w", key, err)
+	}
+
+	s.bus.publish(Event{Key: key, Deleted: true})
+	return nil
+}
+
+// Prefix returns all key/value pairs whose key starts with prefix.
+func (s *Store) Prefix(ctx context.Context, prefix string) (map[string][]byte, error) {
+	if err := s.checkACL(ctx, OpGet, prefix); err != nil {
+		return nil, err
+	}
+
+	results := make(map[string][]byte)
+	prefixBytes := []byte(prefix)
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		c := tx.Bucket(s.bucket).Cursor()
+		for k, v := c.Seek(prefixBytes); k != nil && bytes.HasPrefix(k, prefixBytes); k, v = c.Next() {
+			results[string(k)] = append([]byte(nil), v...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("kv: prefix %s: %w", prefix, err)
+	}
+	return results, nil
+}
+
+func (s *Store) checkACL(ctx context.Context, op Op, key string) error {
+	if s.acl == nil {
+		return nil
+	}
+	return s.acl(ctx, op, key)
+}
@@ -0,0 +1,106 @@
+package kv
+
+import "sync"
+
+// Event describes a change to a single key, delivered to Subscribers.
+type Event struct {
+	Key     string
+	Value   []byte
+	Deleted bool
+}
+
+// subscriberBuffer bounds how many unconsumed events a slow subscriber can
+// accumulate before new events are dropped for it; writers never block on
+// a slow reader.
+const subscriberBuffer = 64
+
+// Subscription is a live feed of Events for keys matching a prefix.
+// Callers must drain C or call Close to avoid leaking the subscription.
+type Subscription struct {
+	id     int
+	prefix string
+	ch     chan Event
+	bus    *bus
+}
+
+// C returns the channel Events are delivered on. It is closed when the
+// Subscription is closed.
+func (s *Subscription) C() <-chan Event {
+	return s.ch
+}
+
+// Close unregisters the subscription and closes its channel.
+func (s *Subscription) Close() {
+	s.bus.unsubscribe(s.id)
+}
+
+// Subscribe returns a Subscription delivering every Set/Delete whose key
+// starts with prefix (an empty prefix matches every key).
+//
+// Example:
+//
+//	sub := store.Subscribe("session/")
+//	defer sub.Close()
+//	for event := range sub.C() {
+//	    log.Printf("changed: %s", event.Key)
+//	}
+func (s *Store) Subscribe(prefix string) *Subscription {
+	return s.bus.subscribe(prefix)
+}
+
+// bus fans out published Events to registered Subscriptions by prefix.
+type bus struct {
+	mu     sync.Mutex
+	nextID int
+	subs   map[int]*Subscription
+}
+
+func newBus() *bus {
+	return &bus{subs: make(map[int]*Subscription)}
+}
+
+func (b *bus) subscribe(prefix string) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	sub := &Subscription{
+		id:     b.nextID,
+		prefix: prefix,
+		ch:     make(chan Event, subscriberBuffer),
+		bus:    b,
+	}
+	b.subs[sub.id] = sub
+	return sub
+}
+
+func (b *bus) unsubscribe(id int) {
+	b.mu.Lock()
+	sub, ok := b.subs[id]
+	delete(b.subs, id)
+	b.mu.Unlock()
+
+	if ok {
+		close(sub.ch)
+	}
+}
+
+func (b *bus) publish(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, sub := range b.subs {
+		if !hasPrefix(event.Key, sub.prefix) {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+			// Slow subscriber; drop rather than block the writer.
+		}
+	}
+}
+
+func hasPrefix(key, prefix string) bool {
+	return len(key) >= len(prefix) && key[:len(prefix)] == prefix
+}
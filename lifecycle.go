@@ -0,0 +1,211 @@
+package gokart
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Hook is one named component in a Lifecycle: something that needs to
+// start (open a connection, spawn a worker) and, on shutdown, stop again
+// in the opposite order — optionally after the other named Hooks listed
+// in its Deps have already started.
+type Hook struct {
+	// Name identifies the hook in logs and dependency-cycle errors.
+	Name string
+
+	// Deps lists the Names of hooks that must finish OnStart before this
+	// hook's OnStart runs. This hook's OnStop then runs before theirs.
+	Deps []string
+
+	// OnStart runs when the Lifecycle starts, in dependency order. A nil
+	// OnStart is treated as an immediate success (useful for a hook that
+	// only needs to run teardown logic on OnStop).
+	OnStart func(ctx context.Context) error
+
+	// OnStop runs during shutdown, in reverse start order. A nil OnStop
+	// is skipped.
+	OnStop func(ctx context.Context) error
+}
+
+// LifecycleConfig configures a Lifecycle.
+type LifecycleConfig struct {
+	// StopTimeout bounds each individual OnStop call. Default: 10s.
+	StopTimeout time.Duration
+
+	// Signals are the signals Run blocks on before initiating shutdown.
+	// Default: SIGINT, SIGTERM.
+	Signals []os.Signal
+
+	// Logger receives start/stop progress. Default: slog.Default().
+	Logger *slog.Logger
+}
+
+func (c LifecycleConfig) withDefaults() LifecycleConfig {
+	if c.StopTimeout == 0 {
+		c.StopTimeout = 10 * time.Second
+	}
+	if len(c.Signals) == 0 {
+		c.Signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	if c.Logger == nil {
+		c.Logger = slog.Default()
+	}
+	return c
+}
+
+// Lifecycle runs a set of named, dependency-ordered components: start
+// them all in topological order, block until a shutdown signal (or the
+// context passed to Run) fires, then stop them in reverse start order
+// with a per-hook deadline. This replaces the ad-hoc `defer db.Close()`
+// chains scaffolded apps otherwise accumulate as more services are
+// added — each service registers one Hook instead.
+//
+// Example:
+//
+//	lc := gokart.NewLifecycle(gokart.LifecycleConfig{})
+//	lc.Register(gokart.Hook{
+//	    Name: "postgres",
+//	    OnStart: func(ctx context.Context) error {
+//	        pool, err = postgres.Open(ctx, dsn)
+//	        return err
+//	    },
+//	    OnStop: func(ctx context.Context) error { pool.Close(); return nil },
+//	})
+//	lc.Register(gokart.Hook{
+//	    Name: "http",
+//	    Deps: []string{"postgres"},
+//	    OnStart: func(ctx context.Context) error { go server.ListenAndServe(); return nil },
+//	    OnStop:  func(ctx context.Context) error { return server.Shutdown(ctx) },
+//	})
+//	if err := lc.Run(context.Background()); err != nil {
+//	    log.Fatal(err)
+//	}
+type Lifecycle struct {
+	cfg LifecycleConfig
+
+	mu    sync.Mutex
+	hooks []Hook
+}
+
+// NewLifecycle returns a Lifecycle ready for Register calls.
+func NewLifecycle(cfg LifecycleConfig) *Lifecycle {
+	return &Lifecycle{cfg: cfg.withDefaults()}
+}
+
+// Register adds hook. Registration order only breaks ties between hooks
+// with no dependency relationship to each other; Deps determines actual
+// start order.
+func (l *Lifecycle) Register(hook Hook) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hooks = append(l.hooks, hook)
+}
+
+// Run starts every registered hook in topological order, blocks until
+// ctx is done or a configured signal arrives, then stops the hooks that
+// started successfully in reverse order. It returns a joined error of
+// any OnStop failures, or the OnStart error that aborted startup.
+func (l *Lifecycle) Run(ctx context.Context) error {
+	l.mu.Lock()
+	hooks := append([]Hook(nil), l.hooks...)
+	l.mu.Unlock()
+
+	order, err := topoSort(hooks)
+	if err != nil {
+		return err
+	}
+
+	started := make([]Hook, 0, len(order))
+	for _, h := range order {
+		if h.OnStart != nil {
+			l.cfg.Logger.Info("lifecycle: starting", "component", h.Name)
+			if err := h.OnStart(ctx); err != nil {
+				stopErr := l.stop(context.Background(), started)
+				return errors.Join(fmt.Errorf("lifecycle: start %q: %w", h.Name, err), stopErr)
+			}
+		}
+		started = append(started, h)
+	}
+
+	sigCtx, stop := signal.NotifyContext(ctx, l.cfg.Signals...)
+	defer stop()
+	<-sigCtx.Done()
+
+	l.cfg.Logger.Info("lifecycle: shutdown signal received, stopping components")
+	return l.stop(context.Background(), started)
+}
+
+func (l *Lifecycle) stop(ctx context.Context, started []Hook) error {
+	var errs []error
+	for i := len(started) - 1; i >= 0; i-- {
+		h := started[i]
+		if h.OnStop == nil {
+			continue
+		}
+
+		l.cfg.Logger.Info("lifecycle: stopping", "component", h.Name)
+		stopCtx, cancel := context.WithTimeout(ctx, l.cfg.StopTimeout)
+		if err := h.OnStop(stopCtx); err != nil {
+			errs = append(errs, fmt.Errorf("stop %q: %w", h.Name, err))
+		}
+		cancel()
+	}
+	return errors.Join(errs...)
+}
+
+// topoSort orders hooks so every Dep precedes its dependent (Kahn's
+// algorithm), breaking ties by registration order for determinism.
+func topoSort(hooks []Hook) ([]Hook, error) {
+	byName := make(map[string]Hook, len(hooks))
+	indegree := make(map[string]int, len(hooks))
+	dependents := make(map[string][]string)
+
+	for _, h := range hooks {
+		byName[h.Name] = h
+		if _, ok := indegree[h.Name]; !ok {
+			indegree[h.Name] = 0
+		}
+	}
+	for _, h := range hooks {
+		for _, dep := range h.Deps {
+			if _, ok := byName[dep]; !ok {
+				return nil, fmt.Errorf("lifecycle: %q depends on unregistered hook %q", h.Name, dep)
+			}
+			indegree[h.Name]++
+			dependents[dep] = append(dependents[dep], h.Name)
+		}
+	}
+
+	var ready []string
+	for _, h := range hooks {
+		if indegree[h.Name] == 0 {
+			ready = append(ready, h.Name)
+		}
+	}
+
+	var order []Hook
+	for len(ready) > 0 {
+		name := ready[0]
+		ready = ready[1:]
+		order = append(order, byName[name])
+
+		for _, dependent := range dependents[name] {
+			indegree[dependent]--
+			if indegree[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	if len(order) != len(hooks) {
+		return nil, fmt.Errorf("lifecycle: dependency cycle detected among hooks")
+	}
+	return order, nil
+}
@@ -0,0 +1,166 @@
+package gokart
+
+import (
+	"context"
+	"fmt"
+)
+
+// Role identifies the speaker of a Message in a chat-style completion.
+type Role string
+
+const (
+	RoleSystem    Role = "system"
+	RoleUser      Role = "user"
+	RoleAssistant Role = "assistant"
+	RoleTool      Role = "tool"
+)
+
+// Message is one turn in a chat-style completion request.
+type Message struct {
+	Role       Role
+	Content    string
+	ToolCallID string     // set on Role: RoleTool, identifying which ToolCall this answers
+	ToolCalls  []ToolCall // set on Role: RoleAssistant, when the model requested tool calls
+}
+
+// ToolSchema describes a function the model may call, in JSON-schema
+// form, for providers that support function-calling / tool-use.
+type ToolSchema struct {
+	Name        string
+	Description string
+	Parameters  map[string]interface{} // JSON schema
+}
+
+// ToolCall is a request from the model to invoke one of the ToolSchemas
+// passed in CompletionRequest.Tools.
+type ToolCall struct {
+	ID        string
+	Name      string
+	Arguments string // JSON-encoded arguments
+}
+
+// CompletionRequest is a provider-agnostic chat completion request.
+type CompletionRequest struct {
+	Model       string
+	Messages    []Message
+	Tools       []ToolSchema
+	Temperature float64
+	MaxTokens   int
+}
+
+// CompletionResponse is a provider-agnostic chat completion result.
+type CompletionResponse struct {
+	Content    string
+	ToolCalls  []ToolCall
+	StopReason string
+	Usage      Usage
+}
+
+// Usage reports token accounting for a completion or embedding call.
+type Usage struct {
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+}
+
+// StreamChunk is one increment of a streamed completion.
+type StreamChunk struct {
+	ContentDelta string
+	ToolCalls    []ToolCall
+	Done         bool
+}
+
+// EmbedRequest asks a Provider to embed one or more strings.
+type EmbedRequest struct {
+	Model string
+	Input []string
+}
+
+// EmbedResponse holds one embedding vector per EmbedRequest.Input entry.
+type EmbedResponse struct {
+	Vectors [][]float32
+	Usage   Usage
+}
+
+// Provider is implemented by every LLM backend gokart ships with
+// (OpenAI, Anthropic, Ollama, llama.cpp) and by GRPCProvider for
+// out-of-process, third-party backends. Scaffolded apps depend only on
+// this interface, so swapping `ai.provider: ollama` for
+// `ai.provider: openai` in config requires no code changes.
+type Provider interface {
+	// Name identifies the backend, e.g. "openai", "anthropic", "ollama".
+	Name() string
+
+	// Complete runs a single, non-streamed chat completion.
+	Complete(ctx context.Context, req CompletionRequest) (CompletionResponse, error)
+
+	// Stream runs a chat completion, invoking onChunk for each increment.
+	// onChunk returning an error aborts the stream with that error.
+	Stream(ctx context.Context, req CompletionRequest, onChunk func(StreamChunk) error) error
+
+	// Embed computes embedding vectors for req.Input.
+	Embed(ctx context.Context, req EmbedRequest) (EmbedResponse, error)
+
+	// Tokenize returns the provider's token count for text, without
+	// making a completion call. Providers without a local tokenizer may
+	// approximate this (e.g. a remote round trip or a heuristic).
+	Tokenize(ctx context.Context, model, text string) (int, error)
+
+	// FunctionCall runs Complete with req.Tools populated and returns
+	// only the resulting tool calls, for callers that only care about
+	// structured function-calling output.
+	FunctionCall(ctx context.Context, req CompletionRequest) ([]ToolCall, error)
+}
+
+// ProviderConfig selects and configures a Provider, matching the
+// `ai.provider: <name>` convention used by scaffolded apps' config.
+type ProviderConfig struct {
+	// Provider selects the backend: "openai", "anthropic", "ollama",
+	// "llamacpp", or "grpc".
+	Provider string
+
+	// APIKey authenticates with OpenAI/Anthropic. Ignored by Ollama,
+	// llama.cpp, and grpc.
+	APIKey string
+
+	// BaseURL overrides the default endpoint (e.g. a self-hosted Ollama
+	// or llama.cpp server, or an OpenAI-compatible gateway). Required for
+	// the grpc provider, where it is the backend's listen address.
+	BaseURL string
+
+	// Command, for the grpc provider, spawns a local backend binary
+	// instead of dialing an already-running one at BaseURL. Mutually
+	// exclusive with BaseURL.
+	Command []string
+}
+
+// NewProvider builds a Provider from cfg.
+//
+// Example:
+//
+//	provider, err := gokart.NewProvider(gokart.ProviderConfig{Provider: "ollama", BaseURL: "http://localhost:11434"})
+func NewProvider(cfg ProviderConfig) (Provider, error) {
+	switch cfg.Provider {
+	case "", "openai":
+		if cfg.APIKey != "" {
+			return NewOpenAIClientWithKey(cfg.APIKey), nil
+		}
+		return NewOpenAIClient(), nil
+	case "anthropic":
+		if cfg.APIKey != "" {
+			return NewAnthropicClientWithKey(cfg.APIKey), nil
+		}
+		return NewAnthropicClient(), nil
+	case "ollama":
+		return NewOllamaClient(cfg.BaseURL), nil
+	case "llamacpp":
+		return NewLlamaCppClient(cfg.BaseURL), nil
+	case "grpc":
+		if len(cfg.Command) > 0 {
+			return NewGRPCProviderProcess(cfg.Command)
+		}
+		return NewGRPCProvider(cfg.BaseURL)
+	default:
+		return nil, fmt.Errorf("gokart: unknown ai provider %q", cfg.Provider)
+	}
+}
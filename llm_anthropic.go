@@ -0,0 +1,198 @@
+package gokart
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/anthropics/anthropic-sdk-go/option"
+)
+
+// AnthropicProvider adapts the official anthropic-sdk-go client to
+// Provider.
+type AnthropicProvider struct {
+	client anthropic.Client
+}
+
+// NewAnthropicClient returns a Provider backed by Claude, reading
+// ANTHROPIC_API_KEY from the environment.
+func NewAnthropicClient() *AnthropicProvider {
+	return &AnthropicProvider{client: anthropic.NewClient()}
+}
+
+// NewAnthropicClientWithKey returns a Provider backed by Claude using an
+// explicit API key.
+func NewAnthropicClientWithKey(apiKey string) *AnthropicProvider {
+	return &AnthropicProvider{client: anthropic.NewClient(option.WithAPIKey(apiKey))}
+}
+
+func (p *AnthropicProvider) Name() string { return "anthropic" }
+
+// splitSystem pulls RoleSystem messages out into Anthropic's separate
+// top-level `system` parameter, since its Messages API (unlike OpenAI's)
+// doesn't accept a system role inline.
+func splitSystem(msgs []Message) (system string, rest []Message) {
+	for _, m := range msgs {
+		if m.Role == RoleSystem {
+			if system != "" {
+				system += "\n"
+			}
+			system += m.Content
+			continue
+		}
+		rest = append(rest, m)
+	}
+	return system, rest
+}
+
+func toAnthropicMessages(msgs []Message) []anthropic.MessageParam {
+	out := make([]anthropic.MessageParam, 0, len(msgs))
+	for _, m := range msgs {
+		switch m.Role {
+		case RoleAssistant:
+			blocks := make([]anthropic.ContentBlockParamUnion, 0, 1+len(m.ToolCalls))
+			if m.Content != "" {
+				blocks = append(blocks, anthropic.NewTextBlock(m.Content))
+			}
+			for _, tc := range m.ToolCalls {
+				var input interface{}
+				if tc.Arguments != "" {
+					_ = json.Unmarshal([]byte(tc.Arguments), &input)
+				}
+				blocks = append(blocks, anthropic.NewToolUseBlock(tc.ID, input, tc.Name))
+			}
+			out = append(out, anthropic.NewAssistantMessage(blocks...))
+		case RoleTool:
+			// A tool result is addressed to the assistant that requested
+			// it, so it goes in a user-role message, per Anthropic's
+			// Messages API, referencing the original tool_use block by ID.
+			out = append(out, anthropic.NewUserMessage(anthropic.NewToolResultBlock(m.ToolCallID, m.Content, false)))
+		default:
+			out = append(out, anthropic.NewUserMessage(anthropic.NewTextBlock(m.Content)))
+		}
+	}
+	return out
+}
+
+func toAnthropicTools(tools []ToolSchema) []anthropic.ToolUnionParam {
+	out := make([]anthropic.ToolUnionParam, 0, len(tools))
+	for _, t := range tools {
+		schema := anthropic.ToolInputSchemaParam{}
+		if props, ok := t.Parameters["properties"]; ok {
+			schema.Properties = props
+		}
+		out = append(out, anthropic.ToolUnionParam{
+			OfTool: &anthropic.ToolParam{
+				Name:        t.Name,
+				Description: anthropic.String(t.Description),
+				InputSchema: schema,
+			},
+		})
+	}
+	return out
+}
+
+func (p *AnthropicProvider) Complete(ctx context.Context, req CompletionRequest) (CompletionResponse, error) {
+	system, messages := splitSystem(req.Messages)
+
+	maxTokens := int64(req.MaxTokens)
+	if maxTokens <= 0 {
+		maxTokens = 1024
+	}
+
+	params := anthropic.MessageNewParams{
+		Model:     anthropic.Model(req.Model),
+		Messages:  toAnthropicMessages(messages),
+		MaxTokens: maxTokens,
+	}
+	if system != "" {
+		params.System = []anthropic.TextBlockParam{{Text: system}}
+	}
+	if len(req.Tools) > 0 {
+		params.Tools = toAnthropicTools(req.Tools)
+	}
+
+	msg, err := p.client.Messages.New(ctx, params)
+	if err != nil {
+		return CompletionResponse{}, fmt.Errorf("anthropic completion: %w", err)
+	}
+
+	resp := CompletionResponse{
+		StopReason: string(msg.StopReason),
+		Usage: Usage{
+			PromptTokens:     int(msg.Usage.InputTokens),
+			CompletionTokens: int(msg.Usage.OutputTokens),
+			TotalTokens:      int(msg.Usage.InputTokens + msg.Usage.OutputTokens),
+		},
+	}
+	for _, block := range msg.Content {
+		if block.Type == "text" {
+			resp.Content += block.Text
+		}
+		if block.Type == "tool_use" {
+			args, _ := json.Marshal(block.Input)
+			resp.ToolCalls = append(resp.ToolCalls, ToolCall{
+				ID:        block.ID,
+				Name:      block.Name,
+				Arguments: string(args),
+			})
+		}
+	}
+	return resp, nil
+}
+
+func (p *AnthropicProvider) Stream(ctx context.Context, req CompletionRequest, onChunk func(StreamChunk) error) error {
+	system, messages := splitSystem(req.Messages)
+
+	maxTokens := int64(req.MaxTokens)
+	if maxTokens <= 0 {
+		maxTokens = 1024
+	}
+
+	params := anthropic.MessageNewParams{
+		Model:     anthropic.Model(req.Model),
+		Messages:  toAnthropicMessages(messages),
+		MaxTokens: maxTokens,
+	}
+	if system != "" {
+		params.System = []anthropic.TextBlockParam{{Text: system}}
+	}
+	if len(req.Tools) > 0 {
+		params.Tools = toAnthropicTools(req.Tools)
+	}
+
+	stream := p.client.Messages.NewStreaming(ctx, params)
+	for stream.Next() {
+		event := stream.Current()
+		if delta, ok := event.AsAny().(anthropic.ContentBlockDeltaEvent); ok && delta.Delta.Text != "" {
+			if err := onChunk(StreamChunk{ContentDelta: delta.Delta.Text}); err != nil {
+				return err
+			}
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return fmt.Errorf("anthropic stream: %w", err)
+	}
+	return onChunk(StreamChunk{Done: true})
+}
+
+// Embed is unsupported: Anthropic does not offer an embeddings API.
+// Callers needing embeddings alongside Claude completions should pair
+// this provider with OpenAIProvider or another embeddings-capable
+// Provider for that call only.
+func (p *AnthropicProvider) Embed(ctx context.Context, req EmbedRequest) (EmbedResponse, error) {
+	return EmbedResponse{}, fmt.Errorf("anthropic: embeddings are not supported")
+}
+
+func (p *AnthropicProvider) Tokenize(ctx context.Context, model, text string) (int, error) {
+	return estimateTokens(text), nil
+}
+
+func (p *AnthropicProvider) FunctionCall(ctx context.Context, req CompletionRequest) ([]ToolCall, error) {
+	resp, err := p.Complete(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.ToolCalls, nil
+}
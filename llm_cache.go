@@ -0,0 +1,212 @@
+package gokart
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// CacheOptions configures CachingProvider.
+type CacheOptions struct {
+	// TTL is how long a cached completion is served before the next call
+	// falls through to the wrapped Provider again. Default: 10 minutes.
+	TTL time.Duration
+
+	// KeyFunc overrides the default cache key derivation (a SHA-256 hash
+	// of model, messages, and tools), e.g. to strip a volatile system
+	// prompt timestamp before hashing.
+	KeyFunc func(CompletionRequest) string
+
+	// ForceCache caches every request regardless of Temperature. By
+	// default only requests with Temperature == 0 (the conventional
+	// signal for deterministic output) are cached, since non-zero
+	// temperatures intentionally vary response to response.
+	ForceCache bool
+
+	// KeyPrefix namespaces cache keys. Default: "llm:".
+	KeyPrefix string
+
+	// Logger receives hit/miss events. Default: slog.Default().
+	Logger *slog.Logger
+}
+
+func (o CacheOptions) withDefaults() CacheOptions {
+	if o.TTL == 0 {
+		o.TTL = 10 * time.Minute
+	}
+	if o.KeyPrefix == "" {
+		o.KeyPrefix = "llm:"
+	}
+	if o.Logger == nil {
+		o.Logger = slog.Default()
+	}
+	if o.KeyFunc == nil {
+		o.KeyFunc = defaultCacheKey
+	}
+	return o
+}
+
+// defaultCacheKey hashes the parts of a CompletionRequest that determine
+// its output: model, messages, and tool schemas.
+func defaultCacheKey(req CompletionRequest) string {
+	data, err := json.Marshal(struct {
+		Model    string
+		Messages []Message
+		Tools    []ToolSchema
+	}{req.Model, req.Messages, req.Tools})
+	if err != nil {
+		// Marshal of these plain types cannot fail; fall back to a
+		// key that simply never matches, so the request is never
+		// incorrectly served stale on the (unreachable) error path.
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// CachingProvider wraps a Provider, serving deterministic completions
+// from a Redis Cache and coalescing concurrent identical requests with
+// singleflight so N simultaneous callers produce one upstream call.
+type CachingProvider struct {
+	next  Provider
+	cache *Cache
+	opts  CacheOptions
+	group singleflight.Group
+}
+
+// NewCachingProvider wraps next so deterministic completions (see
+// CacheOptions.ForceCache) are cached in cache.
+func NewCachingProvider(next Provider, cache *Cache, opts CacheOptions) *CachingProvider {
+	return &CachingProvider{next: next, cache: cache, opts: opts.withDefaults()}
+}
+
+// NewOpenAIClientWithCache returns an OpenAI-backed Provider wrapped with
+// response caching and request deduplication.
+//
+// Example:
+//
+//	cache, _ := gokart.OpenCacheURL(ctx, os.Getenv("REDIS_URL"))
+//	provider := gokart.NewOpenAIClientWithCache(cache, gokart.CacheOptions{TTL: time.Hour})
+//	resp, err := provider.Complete(ctx, gokart.CompletionRequest{
+//	    Model:       openai.ChatModelGPT4oMini,
+//	    Temperature: 0, // eligible for caching
+//	    Messages:    []gokart.Message{{Role: gokart.RoleUser, Content: "hi"}},
+//	})
+func NewOpenAIClientWithCache(cache *Cache, opts CacheOptions) Provider {
+	return NewCachingProvider(NewOpenAIClient(), cache, opts)
+}
+
+func (p *CachingProvider) Name() string { return p.next.Name() }
+
+func (p *CachingProvider) shouldCache(req CompletionRequest) bool {
+	return p.opts.ForceCache || req.Temperature == 0
+}
+
+func (p *CachingProvider) cacheKey(req CompletionRequest) string {
+	key := p.opts.KeyFunc(req)
+	if key == "" {
+		return ""
+	}
+	return p.opts.KeyPrefix + key
+}
+
+// Complete serves req from cache when eligible, otherwise delegates to
+// the wrapped Provider and populates the cache for next time.
+// Concurrent calls with the same cache key share one upstream call.
+func (p *CachingProvider) Complete(ctx context.Context, req CompletionRequest) (CompletionResponse, error) {
+	if !p.shouldCache(req) {
+		return p.next.Complete(ctx, req)
+	}
+
+	key := p.cacheKey(req)
+	if key == "" {
+		return p.next.Complete(ctx, req)
+	}
+
+	var cached CompletionResponse
+	if err := p.cache.GetJSON(ctx, key, &cached); err == nil {
+		p.opts.Logger.Debug("llm cache hit", "provider", p.next.Name(), "key", key)
+		return cached, nil
+	}
+
+	result, err, _ := p.group.Do(key, func() (interface{}, error) {
+		resp, err := p.next.Complete(ctx, req)
+		if err != nil {
+			return CompletionResponse{}, err
+		}
+		if err := p.cache.SetJSON(ctx, key, resp, p.opts.TTL); err != nil {
+			p.opts.Logger.Warn("llm cache write failed", "provider", p.next.Name(), "key", key, "error", err)
+		}
+		return resp, nil
+	})
+	if err != nil {
+		return CompletionResponse{}, err
+	}
+
+	p.opts.Logger.Debug("llm cache miss", "provider", p.next.Name(), "key", key)
+	return result.(CompletionResponse), nil
+}
+
+// Stream serves req from cache when eligible, replaying the cached
+// delta sequence chunk-by-chunk so onChunk sees the same shape it would
+// for a live stream. On a cache miss it streams live from the wrapped
+// Provider while recording deltas, then caches the full sequence for
+// the next identical request.
+func (p *CachingProvider) Stream(ctx context.Context, req CompletionRequest, onChunk func(StreamChunk) error) error {
+	if !p.shouldCache(req) {
+		return p.next.Stream(ctx, req, onChunk)
+	}
+
+	key := p.cacheKey(req)
+	if key == "" {
+		return p.next.Stream(ctx, req, onChunk)
+	}
+
+	var chunks []StreamChunk
+	if err := p.cache.GetJSON(ctx, key, &chunks); err == nil {
+		p.opts.Logger.Debug("llm stream cache hit", "provider", p.next.Name(), "key", key)
+		for _, chunk := range chunks {
+			if err := onChunk(chunk); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	p.opts.Logger.Debug("llm stream cache miss", "provider", p.next.Name(), "key", key)
+
+	var recorded []StreamChunk
+	err := p.next.Stream(ctx, req, func(chunk StreamChunk) error {
+		recorded = append(recorded, chunk)
+		return onChunk(chunk)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := p.cache.SetJSON(ctx, key, recorded, p.opts.TTL); err != nil {
+		p.opts.Logger.Warn("llm stream cache write failed", "provider", p.next.Name(), "key", key, "error", err)
+	}
+	return nil
+}
+
+func (p *CachingProvider) Embed(ctx context.Context, req EmbedRequest) (EmbedResponse, error) {
+	return p.next.Embed(ctx, req)
+}
+
+func (p *CachingProvider) Tokenize(ctx context.Context, model, text string) (int, error) {
+	return p.next.Tokenize(ctx, model, text)
+}
+
+func (p *CachingProvider) FunctionCall(ctx context.Context, req CompletionRequest) ([]ToolCall, error) {
+	resp, err := p.Complete(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.ToolCalls, nil
+}
@@ -0,0 +1,338 @@
+package gokart
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os/exec"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// GRPCProvider fronts an out-of-process LLM backend implementing the
+// gokart.llm.LLM service (see llm/proto/llm.proto), so third parties can
+// write a Provider in any language gRPC supports. It health-checks the
+// backend on connect and, when spawned via NewGRPCProviderProcess,
+// restarts a crashed backend and reconnects automatically.
+type GRPCProvider struct {
+	addr string
+	conn *grpc.ClientConn
+
+	mu      sync.Mutex
+	cmd     *exec.Cmd
+	command []string // set when this provider owns the backend process
+	closed  bool
+}
+
+// NewGRPCProvider dials an already-running backend at addr (plaintext;
+// put a TLS-terminating proxy in front for untrusted networks) and
+// verifies it's healthy via the standard gRPC health-checking protocol.
+//
+// Example:
+//
+//	provider, err := gokart.NewGRPCProvider("localhost:9090")
+func NewGRPCProvider(addr string) (*GRPCProvider, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("dial grpc provider %q: %w", addr, err)
+	}
+
+	p := &GRPCProvider{addr: addr, conn: conn}
+	if err := p.waitHealthy(10 * time.Second); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+// NewGRPCProviderProcess spawns command as a backend process, passing it
+// a free loopback port via "--listen=host:port", waits for it to report
+// healthy, and supervises it for the lifetime of the returned provider:
+// if the process exits, it is restarted (with backoff) and the client
+// connection is rebuilt against the new instance.
+//
+// Example:
+//
+//	provider, err := gokart.NewGRPCProviderProcess([]string{"./backends/llama-grpc"})
+func NewGRPCProviderProcess(command []string) (*GRPCProvider, error) {
+	if len(command) == 0 {
+		return nil, fmt.Errorf("grpc provider: empty command")
+	}
+
+	p := &GRPCProvider{command: command}
+	if err := p.spawn(); err != nil {
+		return nil, err
+	}
+
+	go p.supervise()
+	return p, nil
+}
+
+// spawn picks a free port, launches p.command with a --listen flag
+// pointing at it, dials the resulting address, and waits for health.
+func (p *GRPCProvider) spawn() error {
+	addr, err := freeLoopbackAddr()
+	if err != nil {
+		return fmt.Errorf("grpc provider: find free port: %w", err)
+	}
+
+	cmd := exec.Command(p.command[0], append(p.command[1:], "--listen="+addr)...)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("grpc provider: start backend %q: %w", p.command[0], err)
+	}
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		_ = cmd.Process.Kill()
+		return fmt.Errorf("grpc provider: dial backend: %w", err)
+	}
+
+	p.mu.Lock()
+	p.addr = addr
+	p.cmd = cmd
+	p.conn = conn
+	p.mu.Unlock()
+
+	if err := p.waitHealthy(30 * time.Second); err != nil {
+		_ = cmd.Process.Kill()
+		conn.Close()
+		return err
+	}
+	return nil
+}
+
+// supervise restarts the backend process (with capped exponential
+// backoff) whenever it exits, until the provider is closed.
+func (p *GRPCProvider) supervise() {
+	backoff := time.Second
+	for {
+		p.mu.Lock()
+		cmd := p.cmd
+		closed := p.closed
+		p.mu.Unlock()
+		if closed || cmd == nil {
+			return
+		}
+
+		_ = cmd.Wait()
+
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			return
+		}
+		p.mu.Unlock()
+
+		time.Sleep(backoff)
+		if backoff < 30*time.Second {
+			backoff *= 2
+		}
+
+		if err := p.spawn(); err == nil {
+			backoff = time.Second
+		}
+	}
+}
+
+func (p *GRPCProvider) waitHealthy(timeout time.Duration) error {
+	p.mu.Lock()
+	conn := p.conn
+	p.mu.Unlock()
+
+	client := healthpb.NewHealthClient(conn)
+	deadline := time.Now().Add(timeout)
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+		cancel()
+		if err == nil && resp.Status == healthpb.HealthCheckResponse_SERVING {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			if err != nil {
+				return fmt.Errorf("grpc provider: backend never became healthy: %w", err)
+			}
+			return fmt.Errorf("grpc provider: backend never became healthy: status %v", resp.GetStatus())
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+}
+
+func freeLoopbackAddr() (string, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	defer l.Close()
+	return l.Addr().String(), nil
+}
+
+// Close stops supervising/restarting the backend, closes the client
+// connection, and (if this provider spawned it) terminates the backend
+// process.
+func (p *GRPCProvider) Close() error {
+	p.mu.Lock()
+	p.closed = true
+	conn := p.conn
+	cmd := p.cmd
+	p.mu.Unlock()
+
+	if cmd != nil && cmd.Process != nil {
+		_ = cmd.Process.Kill()
+	}
+	if conn != nil {
+		return conn.Close()
+	}
+	return nil
+}
+
+func (p *GRPCProvider) Name() string { return "grpc" }
+
+func (p *GRPCProvider) currentConn() *grpc.ClientConn {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.conn
+}
+
+// structFromValue round-trips v through JSON into a google.protobuf.Struct,
+// since gokart's request/response types don't themselves implement
+// proto.Message (see llm/proto/llm.proto for why the wire contract uses
+// Struct instead of dedicated messages).
+func structFromValue(v interface{}) (*structpb.Struct, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return structpb.NewStruct(m)
+}
+
+func valueFromStruct(s *structpb.Struct, dest interface{}) error {
+	data, err := json.Marshal(s.AsMap())
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dest)
+}
+
+func (p *GRPCProvider) Complete(ctx context.Context, req CompletionRequest) (CompletionResponse, error) {
+	in, err := structFromValue(req)
+	if err != nil {
+		return CompletionResponse{}, fmt.Errorf("grpc provider: encode request: %w", err)
+	}
+
+	out := new(structpb.Struct)
+	if err := p.currentConn().Invoke(ctx, "/gokart.llm.LLM/Predict", in, out); err != nil {
+		return CompletionResponse{}, fmt.Errorf("grpc provider: predict: %w", err)
+	}
+
+	var resp CompletionResponse
+	if err := valueFromStruct(out, &resp); err != nil {
+		return CompletionResponse{}, fmt.Errorf("grpc provider: decode response: %w", err)
+	}
+	return resp, nil
+}
+
+func (p *GRPCProvider) Stream(ctx context.Context, req CompletionRequest, onChunk func(StreamChunk) error) error {
+	in, err := structFromValue(req)
+	if err != nil {
+		return fmt.Errorf("grpc provider: encode request: %w", err)
+	}
+
+	desc := &grpc.StreamDesc{ServerStreams: true}
+	stream, err := p.currentConn().NewStream(ctx, desc, "/gokart.llm.LLM/PredictStream")
+	if err != nil {
+		return fmt.Errorf("grpc provider: open stream: %w", err)
+	}
+	if err := stream.SendMsg(in); err != nil {
+		return fmt.Errorf("grpc provider: send stream request: %w", err)
+	}
+	if err := stream.CloseSend(); err != nil {
+		return fmt.Errorf("grpc provider: close stream send: %w", err)
+	}
+
+	for {
+		out := new(structpb.Struct)
+		err := stream.RecvMsg(out)
+		if err == io.EOF {
+			return onChunk(StreamChunk{Done: true})
+		}
+		if err != nil {
+			if status.Code(err) == codes.Canceled {
+				return ctx.Err()
+			}
+			return fmt.Errorf("grpc provider: recv stream chunk: %w", err)
+		}
+
+		var chunk StreamChunk
+		if err := valueFromStruct(out, &chunk); err != nil {
+			return fmt.Errorf("grpc provider: decode stream chunk: %w", err)
+		}
+		if err := onChunk(chunk); err != nil {
+			return err
+		}
+		if chunk.Done {
+			return nil
+		}
+	}
+}
+
+func (p *GRPCProvider) Embed(ctx context.Context, req EmbedRequest) (EmbedResponse, error) {
+	in, err := structFromValue(req)
+	if err != nil {
+		return EmbedResponse{}, fmt.Errorf("grpc provider: encode request: %w", err)
+	}
+
+	out := new(structpb.Struct)
+	if err := p.currentConn().Invoke(ctx, "/gokart.llm.LLM/Embed", in, out); err != nil {
+		return EmbedResponse{}, fmt.Errorf("grpc provider: embed: %w", err)
+	}
+
+	var resp EmbedResponse
+	if err := valueFromStruct(out, &resp); err != nil {
+		return EmbedResponse{}, fmt.Errorf("grpc provider: decode response: %w", err)
+	}
+	return resp, nil
+}
+
+// Tokenize has no dedicated RPC; it falls back to the same coarse
+// estimate the built-in HTTP-based providers use.
+func (p *GRPCProvider) Tokenize(ctx context.Context, model, text string) (int, error) {
+	return estimateTokens(text), nil
+}
+
+func (p *GRPCProvider) FunctionCall(ctx context.Context, req CompletionRequest) ([]ToolCall, error) {
+	resp, err := p.Complete(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.ToolCalls, nil
+}
+
+// LoadModel asks the backend to load/swap its active model ahead of the
+// first Predict/Embed call.
+func (p *GRPCProvider) LoadModel(ctx context.Context, model, path string) error {
+	in, err := structpb.NewStruct(map[string]interface{}{"model": model, "path": path})
+	if err != nil {
+		return err
+	}
+
+	out := new(structpb.Struct)
+	if err := p.currentConn().Invoke(ctx, "/gokart.llm.LLM/LoadModel", in, out); err != nil {
+		return fmt.Errorf("grpc provider: load model: %w", err)
+	}
+	return nil
+}
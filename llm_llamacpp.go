@@ -0,0 +1,163 @@
+package gokart
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// LlamaCppProvider talks to a local llama.cpp server, which exposes an
+// OpenAI-compatible `/v1/chat/completions` endpoint — so rather than a
+// second SDK dependency, this reuses the request/response shapes OpenAI
+// already standardized, via plain net/http.
+type LlamaCppProvider struct {
+	baseURL string
+	http    *http.Client
+}
+
+// NewLlamaCppClient returns a Provider backed by a local llama.cpp
+// server. baseURL defaults to "http://localhost:8080".
+func NewLlamaCppClient(baseURL string) *LlamaCppProvider {
+	if baseURL == "" {
+		baseURL = "http://localhost:8080"
+	}
+	return &LlamaCppProvider{baseURL: baseURL, http: &http.Client{Timeout: 5 * time.Minute}}
+}
+
+func (p *LlamaCppProvider) Name() string { return "llamacpp" }
+
+type llamaCppMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type llamaCppChatRequest struct {
+	Model    string            `json:"model,omitempty"`
+	Messages []llamaCppMessage `json:"messages"`
+	Stream   bool              `json:"stream"`
+}
+
+type llamaCppChatResponse struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+		FinishReason string `json:"finish_reason"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+func toLlamaCppMessages(msgs []Message) []llamaCppMessage {
+	out := make([]llamaCppMessage, len(msgs))
+	for i, m := range msgs {
+		out[i] = llamaCppMessage{Role: string(m.Role), Content: m.Content}
+	}
+	return out
+}
+
+func (p *LlamaCppProvider) post(ctx context.Context, req llamaCppChatRequest) (*http.Response, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/v1/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.http.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("llama.cpp request: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("llama.cpp request: unexpected status %s", resp.Status)
+	}
+	return resp, nil
+}
+
+func (p *LlamaCppProvider) Complete(ctx context.Context, req CompletionRequest) (CompletionResponse, error) {
+	resp, err := p.post(ctx, llamaCppChatRequest{Model: req.Model, Messages: toLlamaCppMessages(req.Messages)})
+	if err != nil {
+		return CompletionResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	var out llamaCppChatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return CompletionResponse{}, fmt.Errorf("llama.cpp decode response: %w", err)
+	}
+	if len(out.Choices) == 0 {
+		return CompletionResponse{}, fmt.Errorf("llama.cpp: no choices returned")
+	}
+
+	return CompletionResponse{
+		Content:    out.Choices[0].Message.Content,
+		StopReason: out.Choices[0].FinishReason,
+		Usage: Usage{
+			PromptTokens:     out.Usage.PromptTokens,
+			CompletionTokens: out.Usage.CompletionTokens,
+			TotalTokens:      out.Usage.TotalTokens,
+		},
+	}, nil
+}
+
+func (p *LlamaCppProvider) Stream(ctx context.Context, req CompletionRequest, onChunk func(StreamChunk) error) error {
+	resp, err := p.post(ctx, llamaCppChatRequest{Model: req.Model, Messages: toLlamaCppMessages(req.Messages), Stream: true})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := strings.TrimPrefix(scanner.Text(), "data: ")
+		if line == "" {
+			continue
+		}
+		if line == "[DONE]" {
+			return onChunk(StreamChunk{Done: true})
+		}
+
+		var chunk llamaCppChatResponse
+		if err := json.Unmarshal([]byte(line), &chunk); err != nil {
+			return fmt.Errorf("llama.cpp decode stream chunk: %w", err)
+		}
+		if len(chunk.Choices) == 0 {
+			continue
+		}
+		if err := onChunk(StreamChunk{ContentDelta: chunk.Choices[0].Delta.Content}); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// Embed always returns an error: this client never calls llama.cpp's
+// embeddings endpoint, which the server only exposes when started with
+// --embedding against an embedding-specific model.
+func (p *LlamaCppProvider) Embed(ctx context.Context, req EmbedRequest) (EmbedResponse, error) {
+	return EmbedResponse{}, fmt.Errorf("llamacpp: Embed is not supported by this provider")
+}
+
+func (p *LlamaCppProvider) Tokenize(ctx context.Context, model, text string) (int, error) {
+	return estimateTokens(text), nil
+}
+
+func (p *LlamaCppProvider) FunctionCall(ctx context.Context, req CompletionRequest) ([]ToolCall, error) {
+	return nil, fmt.Errorf("llamacpp: function calling is not supported by this provider")
+}
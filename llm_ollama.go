@@ -0,0 +1,181 @@
+package gokart
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// OllamaProvider talks to a local or remote Ollama server's HTTP API
+// directly — Ollama has no official Go SDK, but its API is small enough
+// that a thin net/http client is the idiomatic wrapper, same as
+// OllamaProvider's sibling LlamaCppProvider.
+type OllamaProvider struct {
+	baseURL string
+	http    *http.Client
+}
+
+// NewOllamaClient returns a Provider backed by Ollama. baseURL defaults
+// to "http://localhost:11434".
+//
+// Example:
+//
+//	provider := gokart.NewOllamaClient("")
+//	resp, err := provider.Complete(ctx, gokart.CompletionRequest{
+//	    Model:    "llama3",
+//	    Messages: []gokart.Message{{Role: gokart.RoleUser, Content: "hi"}},
+//	})
+func NewOllamaClient(baseURL string) *OllamaProvider {
+	if baseURL == "" {
+		baseURL = "http://localhost:11434"
+	}
+	return &OllamaProvider{baseURL: baseURL, http: &http.Client{Timeout: 2 * time.Minute}}
+}
+
+func (p *OllamaProvider) Name() string { return "ollama" }
+
+type ollamaChatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type ollamaChatRequest struct {
+	Model    string              `json:"model"`
+	Messages []ollamaChatMessage `json:"messages"`
+	Stream   bool                `json:"stream"`
+}
+
+type ollamaChatResponse struct {
+	Message struct {
+		Role    string `json:"role"`
+		Content string `json:"content"`
+	} `json:"message"`
+	Done            bool `json:"done"`
+	PromptEvalCount int  `json:"prompt_eval_count"`
+	EvalCount       int  `json:"eval_count"`
+}
+
+func toOllamaMessages(msgs []Message) []ollamaChatMessage {
+	out := make([]ollamaChatMessage, len(msgs))
+	for i, m := range msgs {
+		out[i] = ollamaChatMessage{Role: string(m.Role), Content: m.Content}
+	}
+	return out
+}
+
+func (p *OllamaProvider) doChat(ctx context.Context, req CompletionRequest, stream bool) (*http.Response, error) {
+	body, err := json.Marshal(ollamaChatRequest{
+		Model:    req.Model,
+		Messages: toOllamaMessages(req.Messages),
+		Stream:   stream,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/api/chat", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.http.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("ollama request: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("ollama request: unexpected status %s", resp.Status)
+	}
+	return resp, nil
+}
+
+func (p *OllamaProvider) Complete(ctx context.Context, req CompletionRequest) (CompletionResponse, error) {
+	resp, err := p.doChat(ctx, req, false)
+	if err != nil {
+		return CompletionResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	var out ollamaChatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return CompletionResponse{}, fmt.Errorf("ollama decode response: %w", err)
+	}
+
+	return CompletionResponse{
+		Content: out.Message.Content,
+		Usage: Usage{
+			PromptTokens:     out.PromptEvalCount,
+			CompletionTokens: out.EvalCount,
+			TotalTokens:      out.PromptEvalCount + out.EvalCount,
+		},
+	}, nil
+}
+
+func (p *OllamaProvider) Stream(ctx context.Context, req CompletionRequest, onChunk func(StreamChunk) error) error {
+	resp, err := p.doChat(ctx, req, true)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		var chunk ollamaChatResponse
+		if err := json.Unmarshal(scanner.Bytes(), &chunk); err != nil {
+			return fmt.Errorf("ollama decode stream chunk: %w", err)
+		}
+		if err := onChunk(StreamChunk{ContentDelta: chunk.Message.Content, Done: chunk.Done}); err != nil {
+			return err
+		}
+		if chunk.Done {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
+
+func (p *OllamaProvider) Embed(ctx context.Context, req EmbedRequest) (EmbedResponse, error) {
+	var vectors [][]float32
+	for _, input := range req.Input {
+		body, err := json.Marshal(map[string]string{"model": req.Model, "prompt": input})
+		if err != nil {
+			return EmbedResponse{}, err
+		}
+
+		httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/api/embeddings", bytes.NewReader(body))
+		if err != nil {
+			return EmbedResponse{}, err
+		}
+		httpReq.Header.Set("Content-Type", "application/json")
+
+		resp, err := p.http.Do(httpReq)
+		if err != nil {
+			return EmbedResponse{}, fmt.Errorf("ollama embed request: %w", err)
+		}
+
+		var out struct {
+			Embedding []float32 `json:"embedding"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&out)
+		resp.Body.Close()
+		if err != nil {
+			return EmbedResponse{}, fmt.Errorf("ollama embed decode: %w", err)
+		}
+
+		vectors = append(vectors, out.Embedding)
+	}
+	return EmbedResponse{Vectors: vectors}, nil
+}
+
+func (p *OllamaProvider) Tokenize(ctx context.Context, model, text string) (int, error) {
+	return estimateTokens(text), nil
+}
+
+func (p *OllamaProvider) FunctionCall(ctx context.Context, req CompletionRequest) ([]ToolCall, error) {
+	return nil, fmt.Errorf("ollama: function calling is not supported by this provider")
+}
@@ -0,0 +1,201 @@
+package gokart
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openai/openai-go/v3"
+	"github.com/openai/openai-go/v3/option"
+)
+
+// OpenAIProvider adapts the official openai-go SDK to Provider.
+type OpenAIProvider struct {
+	client openai.Client
+}
+
+// NewOpenAIClient returns a Provider backed by OpenAI, reading
+// OPENAI_API_KEY from the environment (the openai-go SDK's default
+// behavior).
+//
+// Example:
+//
+//	provider := gokart.NewOpenAIClient()
+//	resp, err := provider.Complete(ctx, gokart.CompletionRequest{
+//	    Model:    openai.ChatModelGPT4oMini,
+//	    Messages: []gokart.Message{{Role: gokart.RoleUser, Content: "hi"}},
+//	})
+func NewOpenAIClient() *OpenAIProvider {
+	return &OpenAIProvider{client: openai.NewClient()}
+}
+
+// NewOpenAIClientWithKey returns a Provider backed by OpenAI using an
+// explicit API key, for managing multiple keys or testing.
+func NewOpenAIClientWithKey(apiKey string) *OpenAIProvider {
+	return &OpenAIProvider{client: openai.NewClient(option.WithAPIKey(apiKey))}
+}
+
+func (p *OpenAIProvider) Name() string { return "openai" }
+
+func toOpenAIMessages(msgs []Message) []openai.ChatCompletionMessageParamUnion {
+	out := make([]openai.ChatCompletionMessageParamUnion, 0, len(msgs))
+	for _, m := range msgs {
+		switch m.Role {
+		case RoleSystem:
+			out = append(out, openai.SystemMessage(m.Content))
+		case RoleAssistant:
+			if len(m.ToolCalls) == 0 {
+				out = append(out, openai.AssistantMessage(m.Content))
+				continue
+			}
+			assistant := openai.ChatCompletionAssistantMessageParam{}
+			if m.Content != "" {
+				assistant.Content = openai.ChatCompletionAssistantMessageParamContentUnion{
+					OfString: openai.String(m.Content),
+				}
+			}
+			for _, tc := range m.ToolCalls {
+				assistant.ToolCalls = append(assistant.ToolCalls, openai.ChatCompletionMessageToolCallParam{
+					ID: tc.ID,
+					Function: openai.ChatCompletionMessageToolCallFunctionParam{
+						Name:      tc.Name,
+						Arguments: tc.Arguments,
+					},
+				})
+			}
+			out = append(out, openai.ChatCompletionMessageParamUnion{OfAssistant: &assistant})
+		case RoleTool:
+			out = append(out, openai.ToolMessage(m.Content, m.ToolCallID))
+		default:
+			out = append(out, openai.UserMessage(m.Content))
+		}
+	}
+	return out
+}
+
+func toOpenAITools(tools []ToolSchema) []openai.ChatCompletionToolParam {
+	out := make([]openai.ChatCompletionToolParam, 0, len(tools))
+	for _, t := range tools {
+		out = append(out, openai.ChatCompletionToolParam{
+			Type: openai.ChatCompletionToolTypeFunction,
+			Function: openai.FunctionDefinitionParam{
+				Name:        t.Name,
+				Description: openai.String(t.Description),
+				Parameters:  openai.FunctionParameters(t.Parameters),
+			},
+		})
+	}
+	return out
+}
+
+func (p *OpenAIProvider) Complete(ctx context.Context, req CompletionRequest) (CompletionResponse, error) {
+	params := openai.ChatCompletionNewParams{
+		Model:    req.Model,
+		Messages: toOpenAIMessages(req.Messages),
+	}
+	if len(req.Tools) > 0 {
+		params.Tools = toOpenAITools(req.Tools)
+	}
+
+	completion, err := p.client.Chat.Completions.New(ctx, params)
+	if err != nil {
+		return CompletionResponse{}, fmt.Errorf("openai completion: %w", err)
+	}
+	if len(completion.Choices) == 0 {
+		return CompletionResponse{}, fmt.Errorf("openai completion: no choices returned")
+	}
+
+	choice := completion.Choices[0]
+	resp := CompletionResponse{
+		Content:    choice.Message.Content,
+		StopReason: string(choice.FinishReason),
+		Usage: Usage{
+			PromptTokens:     int(completion.Usage.PromptTokens),
+			CompletionTokens: int(completion.Usage.CompletionTokens),
+			TotalTokens:      int(completion.Usage.TotalTokens),
+		},
+	}
+	for _, tc := range choice.Message.ToolCalls {
+		resp.ToolCalls = append(resp.ToolCalls, ToolCall{
+			ID:        tc.ID,
+			Name:      tc.Function.Name,
+			Arguments: tc.Function.Arguments,
+		})
+	}
+	return resp, nil
+}
+
+func (p *OpenAIProvider) Stream(ctx context.Context, req CompletionRequest, onChunk func(StreamChunk) error) error {
+	params := openai.ChatCompletionNewParams{
+		Model:    req.Model,
+		Messages: toOpenAIMessages(req.Messages),
+	}
+
+	stream := p.client.Chat.Completions.NewStreaming(ctx, params)
+	for stream.Next() {
+		event := stream.Current()
+		if len(event.Choices) == 0 {
+			continue
+		}
+		delta := event.Choices[0].Delta
+		if err := onChunk(StreamChunk{ContentDelta: delta.Content}); err != nil {
+			return err
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return fmt.Errorf("openai stream: %w", err)
+	}
+	return onChunk(StreamChunk{Done: true})
+}
+
+func (p *OpenAIProvider) Embed(ctx context.Context, req EmbedRequest) (EmbedResponse, error) {
+	model := req.Model
+	if model == "" {
+		model = "text-embedding-3-small"
+	}
+
+	resp, err := p.client.Embeddings.New(ctx, openai.EmbeddingNewParams{
+		Model: model,
+		Input: openai.EmbeddingNewParamsInputUnion{OfArrayOfStrings: req.Input},
+	})
+	if err != nil {
+		return EmbedResponse{}, fmt.Errorf("openai embed: %w", err)
+	}
+
+	out := EmbedResponse{
+		Vectors: make([][]float32, len(resp.Data)),
+		Usage: Usage{
+			PromptTokens: int(resp.Usage.PromptTokens),
+			TotalTokens:  int(resp.Usage.TotalTokens),
+		},
+	}
+	for i, d := range resp.Data {
+		vec := make([]float32, len(d.Embedding))
+		for j, f := range d.Embedding {
+			vec[j] = float32(f)
+		}
+		out.Vectors[i] = vec
+	}
+	return out, nil
+}
+
+// Tokenize makes no API call; it returns the coarse
+// ~4-characters-per-token estimate from estimateTokens. Use a dedicated
+// tokenizer library for exact counts.
+func (p *OpenAIProvider) Tokenize(ctx context.Context, model, text string) (int, error) {
+	return estimateTokens(text), nil
+}
+
+func (p *OpenAIProvider) FunctionCall(ctx context.Context, req CompletionRequest) ([]ToolCall, error) {
+	resp, err := p.Complete(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.ToolCalls, nil
+}
+
+// estimateTokens approximates a token count as ~4 characters per token,
+// the same rule of thumb OpenAI documents for English text, for
+// providers/code paths with no access to a real tokenizer.
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
@@ -0,0 +1,132 @@
+package gokart_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/dotcommander/gokart"
+)
+
+func newTestCache(t *testing.T) *gokart.Cache {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+
+	cfg := gokart.DefaultCacheConfig()
+	cfg.Addr = mr.Addr()
+
+	cache, err := gokart.OpenCacheWithConfig(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("OpenCacheWithConfig: %v", err)
+	}
+	t.Cleanup(func() { cache.Close() })
+
+	return cache
+}
+
+func TestLockAcquireAndUnlock(t *testing.T) {
+	t.Parallel()
+
+	cache := newTestCache(t)
+	ctx := context.Background()
+
+	lock, err := cache.Lock(ctx, "invoice:123", gokart.LockOptions{TTL: time.Second})
+	if err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+
+	if _, err := cache.Lock(ctx, "invoice:123", gokart.LockOptions{
+		RetryStrategy: gokart.RetryStrategy{MaxAttempts: 1},
+	}); err != gokart.ErrLockNotAcquired {
+		t.Fatalf("expected ErrLockNotAcquired while held, got %v", err)
+	}
+
+	if err := lock.Unlock(ctx); err != nil {
+		t.Fatalf("Unlock: %v", err)
+	}
+
+	lock2, err := cache.Lock(ctx, "invoice:123", gokart.LockOptions{TTL: time.Second})
+	if err != nil {
+		t.Fatalf("Lock after unlock: %v", err)
+	}
+	_ = lock2.Unlock(ctx)
+}
+
+func TestLockUnlockWrongToken(t *testing.T) {
+	t.Parallel()
+
+	cache := newTestCache(t)
+	ctx := context.Background()
+
+	lock, err := cache.Lock(ctx, "job:1", gokart.LockOptions{TTL: time.Second})
+	if err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+
+	// Simulate a stolen lock: force the key to a different holder and
+	// verify Unlock refuses to release it.
+	if err := cache.Set(ctx, "job:1", "someone-else", time.Second); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	if err := lock.Unlock(ctx); err != gokart.ErrLockLost {
+		t.Fatalf("expected ErrLockLost, got %v", err)
+	}
+}
+
+func TestWithLockRunsAndReleases(t *testing.T) {
+	t.Parallel()
+
+	cache := newTestCache(t)
+	ctx := context.Background()
+
+	ran := false
+	err := gokart.WithLock(ctx, cache, "report:daily", gokart.LockOptions{TTL: time.Second}, func(ctx context.Context) error {
+		ran = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithLock: %v", err)
+	}
+	if !ran {
+		t.Fatal("expected fn to run")
+	}
+
+	lock, err := cache.Lock(ctx, "report:daily", gokart.LockOptions{TTL: time.Second})
+	if err != nil {
+		t.Fatalf("expected lock to be released, got: %v", err)
+	}
+	_ = lock.Unlock(ctx)
+}
+
+func TestLockAutoRenew(t *testing.T) {
+	t.Parallel()
+
+	cache := newTestCache(t)
+	ctx := context.Background()
+
+	lock, err := cache.Lock(ctx, "session:42", gokart.LockOptions{
+		TTL:       150 * time.Millisecond,
+		AutoRenew: true,
+	})
+	if err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+
+	// Outlive the original TTL; auto-renew should have kept it alive.
+	time.Sleep(400 * time.Millisecond)
+
+	valid, err := lock.Valid(ctx)
+	if err != nil {
+		t.Fatalf("Valid: %v", err)
+	}
+	if !valid {
+		t.Fatal("expected lock to still be valid thanks to auto-renew")
+	}
+
+	if err := lock.Unlock(ctx); err != nil {
+		t.Fatalf("Unlock: %v", err)
+	}
+}
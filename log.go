@@ -1,11 +1,14 @@
 package gokart
 
 import (
+	"context"
 	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/dotcommander/gokart/logger"
 )
 
 // LogConfig configures structured logging behavior.
@@ -13,6 +16,9 @@ type LogConfig struct {
 	Level  string    // debug, info, warn, error (default: info)
 	Format string    // json, text (default: json)
 	Output io.Writer // default: os.Stderr
+
+	// Rotation configures size/age-based rotation for NewFileLoggerWithConfig.
+	Rotation logger.RotationConfig
 }
 
 // NewLogger creates a new structured logger with sensible defaults.
@@ -89,23 +95,61 @@ func parseLogLevel(level string) slog.Level {
 //	log.Info("application started")
 //	// Logs written to /tmp/myapp.log (or equivalent)
 func NewFileLogger(appName string) (*slog.Logger, func(), error) {
-	path := LogPath(appName)
-
-	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return nil, func() {}, err
-	}
+	return logger.NewFileWithConfig(appName, logger.Config{})
+}
 
-	handler := slog.NewJSONHandler(file, &slog.HandlerOptions{
-		Level: slog.LevelDebug,
+// NewFileLoggerWithConfig is NewFileLogger with rotation control via
+// cfg.Rotation (size and/or age thresholds, optionally gzipping
+// rotated-out files).
+//
+// Example:
+//
+//	log, cleanup, err := gokart.NewFileLoggerWithConfig("myapp", gokart.LogConfig{
+//	    Rotation: logger.RotationConfig{MaxSizeBytes: 50 << 20, Compress: true},
+//	})
+func NewFileLoggerWithConfig(appName string, cfg LogConfig) (*slog.Logger, func(), error) {
+	return logger.NewFileWithConfig(appName, logger.Config{
+		Level:    cfg.Level,
+		Format:   cfg.Format,
+		Rotation: cfg.Rotation,
 	})
-	logger := slog.New(handler)
+}
 
-	cleanup := func() {
-		file.Close()
-	}
+// LogFromContext returns the logger attached to ctx by LogWithContext,
+// or slog.Default() if none was attached.
+func LogFromContext(ctx context.Context) *slog.Logger {
+	return logger.FromContext(ctx)
+}
+
+// LogWithContext returns a copy of ctx carrying log, retrievable later
+// with LogFromContext.
+func LogWithContext(ctx context.Context, log *slog.Logger) context.Context {
+	return logger.WithContext(ctx, log)
+}
+
+// NewLeveledLogger builds a logger.Logger from cfg, the pluggable
+// hclog-style interface (Trace/Debug/Info/Warn/Error, With, Named,
+// SetLevel) rather than a bare *slog.Logger. Use this plus
+// logger.MiddlewareLeveled and LoggerFromContext when call sites need a
+// per-subsystem named logger instead of NewLogger's flat *slog.Logger.
+//
+// Example:
+//
+//	log := gokart.NewLeveledLogger(gokart.LogConfig{Level: "info"})
+//	router.Use(logger.MiddlewareLeveled(log))
+func NewLeveledLogger(cfg LogConfig) logger.Logger {
+	return logger.NewLeveled(logger.Config{
+		Level:  cfg.Level,
+		Format: cfg.Format,
+		Output: cfg.Output,
+	})
+}
 
-	return logger, cleanup, nil
+// LoggerFromContext returns the logger.Logger attached to ctx by
+// logger.MiddlewareLeveled (or logger.WithContextLeveled directly), or
+// logger.Default() if ctx carries none.
+func LoggerFromContext(ctx context.Context) logger.Logger {
+	return logger.FromContextLeveled(ctx)
 }
 
 // LogPath returns the path where file logs are written.
@@ -1,6 +1,7 @@
 package gokart_test
 
 import (
+	"context"
 	"os"
 	"strings"
 	"testing"
@@ -95,3 +96,30 @@ func TestNewFileLogger_Append(t *testing.T) {
 		t.Error("log should contain second message")
 	}
 }
+
+func TestNewLeveledLogger_SetLevelGatesEmission(t *testing.T) {
+	t.Parallel()
+
+	var buf strings.Builder
+	log := gokart.NewLeveledLogger(gokart.LogConfig{Level: "info", Format: "text", Output: &buf})
+
+	log.Debug("should not appear")
+	if strings.Contains(buf.String(), "should not appear") {
+		t.Error("Debug should be gated out at Info level")
+	}
+
+	log.SetLevel("debug")
+	log.Debug("should appear")
+	if !strings.Contains(buf.String(), "should appear") {
+		t.Error("Debug should be emitted after SetLevel(\"debug\")")
+	}
+}
+
+func TestLoggerFromContext_DefaultsWhenUnset(t *testing.T) {
+	t.Parallel()
+
+	log := gokart.LoggerFromContext(context.Background())
+	if log == nil {
+		t.Fatal("expected a non-nil default logger")
+	}
+}
@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+)
+
+type ctxKey struct{}
+
+// WithContext returns a copy of ctx carrying log, retrievable later with
+// FromContext, so request-scoped attributes (trace id, user id, request
+// id) attached via log.With(...) propagate through handlers and DB calls
+// without threading a *slog.Logger through every function signature.
+//
+// Example:
+//
+//	ctx = logger.WithContext(ctx, log.With("request_id", reqID))
+func WithContext(ctx context.Context, log *slog.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, log)
+}
+
+// FromContext returns the logger stored by WithContext, or
+// slog.Default() if ctx carries none.
+//
+// Example:
+//
+//	logger.FromContext(ctx).Info("processing order", "order_id", id)
+func FromContext(ctx context.Context) *slog.Logger {
+	if log, ok := ctx.Value(ctxKey{}).(*slog.Logger); ok {
+		return log
+	}
+	return slog.Default()
+}
+
+type leveledCtxKey struct{}
+
+// WithContextLeveled is WithContext for the Logger interface, used by
+// MiddlewareLeveled to propagate a request-scoped named child logger.
+//
+// Example:
+//
+//	ctx = logger.WithContextLeveled(ctx, log.Named("http").With("request_id", reqID))
+func WithContextLeveled(ctx context.Context, log Logger) context.Context {
+	return context.WithValue(ctx, leveledCtxKey{}, log)
+}
+
+// FromContextLeveled returns the Logger stored by WithContextLeveled, or
+// Default() if ctx carries none.
+func FromContextLeveled(ctx context.Context) Logger {
+	if log, ok := ctx.Value(leveledCtxKey{}).(Logger); ok {
+		return log
+	}
+	return Default()
+}
@@ -0,0 +1,167 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+)
+
+// LevelTrace is one step more verbose than slog.LevelDebug. slog has no
+// built-in level below Debug, so Logger.Trace uses this instead.
+const LevelTrace = slog.Level(-8)
+
+// Logger is a pluggable structured-logging interface in the hclog
+// tradition: leveled methods, With/Named for attaching context and
+// building a per-subsystem hierarchy, and SetLevel for runtime level
+// changes. Call sites written against Logger don't change when the
+// backend does — swap the value returned by NewLeveled (or SetDefault)
+// for another implementation (NopLogger, a third-party adapter) and
+// every With/Named caller keeps working.
+type Logger interface {
+	Trace(msg string, args ...any)
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+
+	// With returns a child logger with args appended to every
+	// subsequent record, same semantics as slog.Logger.With.
+	With(args ...any) Logger
+
+	// Named returns a child logger scoped to name, dot-joined onto any
+	// existing name (so Named("http").Named("router") -> "http.router"),
+	// with its level seeded from the GOKART_LOG_LEVEL_<name> env var if
+	// set, otherwise inherited from the parent's current level.
+	Named(name string) Logger
+
+	// SetLevel changes this logger's (and its With-derived children's,
+	// since they share the same level) minimum emitted level at
+	// runtime. Named children seed their own level at creation and are
+	// unaffected by a parent's later SetLevel calls.
+	SetLevel(level string)
+}
+
+// slogLogger is Logger's default implementation, backed by *slog.Logger
+// with an independent level gate so SetLevel works regardless of what
+// level the underlying handler was built with.
+type slogLogger struct {
+	log   *slog.Logger
+	level *slog.LevelVar
+	name  string
+}
+
+// NewLeveled builds a Logger with the same Config semantics as New,
+// except its level is held in a slog.LevelVar so SetLevel (and Named's
+// per-subsystem env overrides) can change it at runtime.
+//
+// Example:
+//
+//	log := logger.NewLeveled(logger.Config{Level: "info", Format: "json"})
+//	log.Info("server started", "port", 8080)
+//	pgLog := log.Named("postgres") // honors GOKART_LOG_LEVEL_postgres
+func NewLeveled(cfg Config) Logger {
+	lv := new(slog.LevelVar)
+	lv.Set(parseLevel(cfg.Level))
+
+	output := cfg.Output
+	if output == nil {
+		output = os.Stderr
+	}
+
+	opts := &slog.HandlerOptions{Level: lv}
+	var handler slog.Handler
+	if strings.ToLower(cfg.Format) == "text" {
+		handler = slog.NewTextHandler(output, opts)
+	} else {
+		handler = slog.NewJSONHandler(output, opts)
+	}
+
+	return &slogLogger{log: slog.New(handler), level: lv}
+}
+
+// WrapSlog adapts an existing *slog.Logger as a Logger. Its level gate
+// starts at Info and is independent of whatever level sl's own handler
+// was built with; SetLevel only ever makes slogLogger emit less than
+// the handler would otherwise allow, never more.
+func WrapSlog(sl *slog.Logger) Logger {
+	lv := new(slog.LevelVar)
+	lv.Set(slog.LevelInfo)
+	return &slogLogger{log: sl, level: lv}
+}
+
+func (l *slogLogger) emit(level slog.Level, msg string, args ...any) {
+	if level < l.level.Level() {
+		return
+	}
+	l.log.Log(context.Background(), level, msg, args...)
+}
+
+func (l *slogLogger) Trace(msg string, args ...any) { l.emit(LevelTrace, msg, args...) }
+func (l *slogLogger) Debug(msg string, args ...any) { l.emit(slog.LevelDebug, msg, args...) }
+func (l *slogLogger) Info(msg string, args ...any)  { l.emit(slog.LevelInfo, msg, args...) }
+func (l *slogLogger) Warn(msg string, args ...any)  { l.emit(slog.LevelWarn, msg, args...) }
+func (l *slogLogger) Error(msg string, args ...any) { l.emit(slog.LevelError, msg, args...) }
+
+func (l *slogLogger) With(args ...any) Logger {
+	return &slogLogger{log: l.log.With(args...), level: l.level, name: l.name}
+}
+
+func (l *slogLogger) Named(name string) Logger {
+	fullName := name
+	if l.name != "" {
+		fullName = l.name + "." + name
+	}
+
+	lv := new(slog.LevelVar)
+	if env := os.Getenv("GOKART_LOG_LEVEL_" + name); env != "" {
+		lv.Set(parseLevel(env))
+	} else {
+		lv.Set(l.level.Level())
+	}
+
+	return &slogLogger{log: l.log.With("logger", fullName), level: lv, name: fullName}
+}
+
+func (l *slogLogger) SetLevel(level string) {
+	l.level.Set(parseLevel(level))
+}
+
+// nopLogger is a Logger that discards everything, useful as a default
+// in libraries embedding gokart that don't want it logging by default,
+// and as a worked example of plugging in an alternative backend.
+type nopLogger struct{}
+
+// NopLogger returns a Logger that discards every record.
+func NopLogger() Logger { return nopLogger{} }
+
+func (nopLogger) Trace(string, ...any)  {}
+func (nopLogger) Debug(string, ...any)  {}
+func (nopLogger) Info(string, ...any)   {}
+func (nopLogger) Warn(string, ...any)   {}
+func (nopLogger) Error(string, ...any)  {}
+func (n nopLogger) With(...any) Logger  { return n }
+func (n nopLogger) Named(string) Logger { return n }
+func (nopLogger) SetLevel(string)       {}
+
+var (
+	defaultMu     sync.RWMutex
+	defaultLogger Logger = WrapSlog(slog.Default())
+)
+
+// SetDefault replaces the package-level default Logger returned by
+// Default, for global swap-out of the logging backend.
+func SetDefault(l Logger) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultLogger = l
+}
+
+// Default returns the package-level default Logger, WrapSlog(slog.Default())
+// until changed with SetDefault.
+func Default() Logger {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultLogger
+}
@@ -14,6 +14,10 @@ type Config struct {
 	Level  string    // debug, info, warn, error (default: info)
 	Format string    // json, text (default: json)
 	Output io.Writer // default: os.Stderr
+
+	// Rotation configures size/age-based rotation for NewFile/NewFileWithConfig.
+	// Ignored by New, which always writes to Output directly.
+	Rotation RotationConfig
 }
 
 // New creates a new structured logger with sensible defaults.
@@ -92,23 +96,47 @@ func parseLevel(level string) slog.Level {
 //	log.Info("application started")
 //	// Logs written to /tmp/myapp.log (or equivalent)
 func NewFile(appName string) (*slog.Logger, func(), error) {
+	return NewFileWithConfig(appName, Config{})
+}
+
+// NewFileWithConfig is NewFile with rotation control via cfg.Rotation
+// (size and/or age thresholds, optionally gzipping rotated-out files)
+// and cfg.Level/cfg.Format applied the same way as New, except that an
+// empty Level means debug rather than New's info.
+//
+// Example:
+//
+//	log, cleanup, err := logger.NewFileWithConfig("myapp", logger.Config{
+//	    Rotation: logger.RotationConfig{MaxSizeBytes: 50 << 20, MaxAge: 24 * time.Hour, Compress: true},
+//	})
+func NewFileWithConfig(appName string, cfg Config) (*slog.Logger, func(), error) {
 	path := Path(appName)
 
-	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	writer, err := NewRotatingWriter(path, cfg.Rotation)
 	if err != nil {
 		return nil, func() {}, err
 	}
 
-	handler := slog.NewJSONHandler(file, &slog.HandlerOptions{
-		Level: slog.LevelDebug,
-	})
-	logger := slog.New(handler)
+	level := slog.LevelDebug
+	if cfg.Level != "" {
+		level = parseLevel(cfg.Level)
+	}
+
+	var handler slog.Handler
+	opts := &slog.HandlerOptions{Level: level}
+	if strings.ToLower(cfg.Format) == "text" {
+		handler = slog.NewTextHandler(writer, opts)
+	} else {
+		handler = slog.NewJSONHandler(writer, opts)
+	}
+
+	log := slog.New(handler)
 
 	cleanup := func() {
-		file.Close()
+		writer.Close()
 	}
 
-	return logger, cleanup, nil
+	return log, cleanup, nil
 }
 
 // Path returns the path where file logs are written.
@@ -0,0 +1,103 @@
+package logger
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// statusRecorder captures the response status for logging after the
+// handler chain runs, since http.ResponseWriter doesn't expose it.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Middleware returns net/http (and therefore chi-compatible) middleware
+// that injects a request-scoped child logger into the request context
+// via WithContext, carrying "method", "path", and "request_id", and logs
+// the completed request with its status and latency.
+//
+// Example:
+//
+//	router := chi.NewRouter()
+//	router.Use(logger.Middleware(log))
+func Middleware(log *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := r.Header.Get("X-Request-ID")
+			if requestID == "" {
+				requestID = newRequestID()
+			}
+
+			reqLog := log.With(
+				"method", r.Method,
+				"path", r.URL.Path,
+				"request_id", requestID,
+			)
+
+			ctx := WithContext(r.Context(), reqLog)
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			start := time.Now()
+			next.ServeHTTP(rec, r.WithContext(ctx))
+
+			reqLog.Info("request completed",
+				"status", rec.status,
+				"latency_ms", time.Since(start).Milliseconds(),
+			)
+		})
+	}
+}
+
+func newRequestID() string {
+	buf := make([]byte, 8)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// MiddlewareLeveled is Middleware for the Logger interface: every
+// request gets log.Named("http").With("method", ..., "path", ...,
+// "request_id", ...), retrievable from the request context via
+// FromContextLeveled (or gokart.LoggerFromContext at the root package).
+//
+// Example:
+//
+//	router := chi.NewRouter()
+//	router.Use(logger.MiddlewareLeveled(logger.Default()))
+func MiddlewareLeveled(log Logger) func(http.Handler) http.Handler {
+	named := log.Named("http")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := r.Header.Get("X-Request-ID")
+			if requestID == "" {
+				requestID = newRequestID()
+			}
+
+			reqLog := named.With(
+				"method", r.Method,
+				"path", r.URL.Path,
+				"request_id", requestID,
+			)
+
+			ctx := WithContextLeveled(r.Context(), reqLog)
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			start := time.Now()
+			next.ServeHTTP(rec, r.WithContext(ctx))
+
+			reqLog.Info("request completed",
+				"status", rec.status,
+				"latency_ms", time.Since(start).Milliseconds(),
+			)
+		})
+	}
+}
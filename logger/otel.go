@@ -0,0 +1,63 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// otelHandler wraps an slog.Handler, additionally recording each log
+// record as a span event on the span found in the record's context (if
+// any and if it's being recorded), so a single log.Info(...) call
+// produces both a JSON line and a span event without separate
+// instrumentation at each call site.
+type otelHandler struct {
+	next slog.Handler
+}
+
+// NewOTELHandler wraps next so records are also added as events on the
+// active OpenTelemetry span, when the context passed to the logging call
+// carries one. Pair with New/NewFileWithConfig by swapping the returned
+// handler:
+//
+// Example:
+//
+//	base := slog.NewJSONHandler(os.Stderr, nil)
+//	log := slog.New(logger.NewOTELHandler(base))
+//	log.InfoContext(ctx, "order placed", "order_id", id) // JSON line + span event
+func NewOTELHandler(next slog.Handler) slog.Handler {
+	return &otelHandler{next: next}
+}
+
+func (h *otelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *otelHandler) Handle(ctx context.Context, record slog.Record) error {
+	if span := trace.SpanFromContext(ctx); span != nil && span.IsRecording() {
+		attrs := make([]attribute.KeyValue, 0, record.NumAttrs()+1)
+		attrs = append(attrs, attribute.String("log.severity", record.Level.String()))
+		record.Attrs(func(a slog.Attr) bool {
+			attrs = append(attrs, attribute.String(a.Key, a.Value.String()))
+			return true
+		})
+		span.AddEvent(record.Message, trace.WithAttributes(attrs...))
+
+		if record.Level >= slog.LevelError {
+			span.SetStatus(codes.Error, record.Message)
+		}
+	}
+
+	return h.next.Handle(ctx, record)
+}
+
+func (h *otelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &otelHandler{next: h.next.WithAttrs(attrs)}
+}
+
+func (h *otelHandler) WithGroup(name string) slog.Handler {
+	return &otelHandler{next: h.next.WithGroup(name)}
+}
@@ -0,0 +1,272 @@
+package logger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RotationConfig configures RotatingWriter's size and age thresholds.
+type RotationConfig struct {
+	// Path is the active log file's path. NewRotatingFile defaults this
+	// to Path(appName) when empty; NewRotatingWriter takes the path as
+	// a separate argument and ignores this field.
+	Path string
+
+	// MaxSizeBytes rotates the file once it exceeds this size. Zero
+	// disables size-based rotation.
+	MaxSizeBytes int64
+
+	// MaxAge rotates the file once it has been open longer than this,
+	// and prunes rotated-out backups older than this. Zero disables
+	// both age-based rotation and age-based pruning.
+	MaxAge time.Duration
+
+	// MaxBackups caps the number of rotated-out files kept, pruning the
+	// oldest first. Zero keeps all backups (subject to MaxAge).
+	MaxBackups int
+
+	// Compress gzips the rotated-out file in the background once a new
+	// one is opened.
+	Compress bool
+
+	// LocalTime timestamps rotated filenames using local time instead
+	// of UTC.
+	LocalTime bool
+}
+
+// RotatingWriter is an io.WriteCloser that appends to a file at path,
+// rotating it out (renaming to path-<timestamp>.log, optionally
+// gzipped) once MaxSizeBytes or MaxAge is exceeded, and pruning older
+// backups beyond MaxBackups/MaxAge. With a zero RotationConfig it
+// behaves like a plain append-only file, same as the raw os.OpenFile
+// NewFile used before rotation support was added.
+type RotatingWriter struct {
+	path string
+	cfg  RotationConfig
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+// NewRotatingWriter opens (or creates) path for appending, ready to
+// rotate according to cfg.
+func NewRotatingWriter(path string, cfg RotationConfig) (*RotatingWriter, error) {
+	w := &RotatingWriter{path: path, cfg: cfg}
+	if err := w.openCurrent(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// NewRotatingFile is NewRotatingWriter for appName, defaulting the path
+// to Path(appName) when cfg.Path is empty.
+//
+// Example:
+//
+//	w, err := logger.NewRotatingFile("myapp", logger.RotationConfig{
+//	    MaxSizeBytes: 50 << 20,
+//	    MaxBackups:   5,
+//	    Compress:     true,
+//	})
+func NewRotatingFile(appName string, cfg RotationConfig) (*RotatingWriter, error) {
+	path := cfg.Path
+	if path == "" {
+		path = Path(appName)
+	}
+	return NewRotatingWriter(path, cfg)
+}
+
+func (w *RotatingWriter) openCurrent() error {
+	file, err := os.OpenFile(w.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("open log file %q: %w", w.path, err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("stat log file %q: %w", w.path, err)
+	}
+
+	w.file = file
+	w.size = info.Size()
+	w.openedAt = time.Now()
+	return nil
+}
+
+func (w *RotatingWriter) shouldRotate(nextWrite int) bool {
+	if w.cfg.MaxSizeBytes > 0 && w.size+int64(nextWrite) > w.cfg.MaxSizeBytes {
+		return true
+	}
+	if w.cfg.MaxAge > 0 && time.Since(w.openedAt) > w.cfg.MaxAge {
+		return true
+	}
+	return false
+}
+
+// Write implements io.Writer, rotating first if needed.
+func (w *RotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.size > 0 && w.shouldRotate(len(p)) {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *RotatingWriter) rotationTime() time.Time {
+	now := time.Now()
+	if !w.cfg.LocalTime {
+		now = now.UTC()
+	}
+	return now
+}
+
+func (w *RotatingWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("close log file %q before rotation: %w", w.path, err)
+	}
+
+	ext := filepath.Ext(w.path)
+	base := strings.TrimSuffix(w.path, ext)
+	rotatedPath := fmt.Sprintf("%s-%s%s", base, w.rotationTime().Format("20060102T150405"), ext)
+	if err := os.Rename(w.path, rotatedPath); err != nil {
+		return fmt.Errorf("rotate log file %q: %w", w.path, err)
+	}
+
+	if w.cfg.Compress {
+		go func() {
+			if err := gzipFile(rotatedPath); err != nil {
+				slog.Default().Warn("gokart: failed to compress rotated log", "path", rotatedPath, "error", err)
+			}
+		}()
+	}
+
+	if err := w.pruneBackups(); err != nil {
+		slog.Default().Warn("gokart: failed to prune rotated logs", "path", w.path, "error", err)
+	}
+
+	return w.openCurrent()
+}
+
+// pruneBackups removes rotated-out files for w.path beyond MaxBackups
+// (oldest first) and older than MaxAge, leaving the active file alone.
+func (w *RotatingWriter) pruneBackups() error {
+	if w.cfg.MaxBackups <= 0 && w.cfg.MaxAge <= 0 {
+		return nil
+	}
+
+	dir := filepath.Dir(w.path)
+	ext := filepath.Ext(w.path)
+	base := filepath.Base(strings.TrimSuffix(w.path, ext))
+	prefix := base + "-"
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("read log dir %q: %w", dir, err)
+	}
+
+	var backups []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		backups = append(backups, name)
+	}
+	sort.Strings(backups) // timestamp suffix sorts lexically = chronologically
+
+	cutoff := time.Time{}
+	if w.cfg.MaxAge > 0 {
+		cutoff = time.Now().Add(-w.cfg.MaxAge)
+	}
+
+	keep := len(backups)
+	if w.cfg.MaxBackups > 0 && keep > w.cfg.MaxBackups {
+		keep = w.cfg.MaxBackups
+	}
+
+	for i, name := range backups {
+		full := filepath.Join(dir, name)
+		tooMany := i < len(backups)-keep
+		tooOld := false
+		if !cutoff.IsZero() {
+			if info, err := os.Stat(full); err == nil {
+				tooOld = info.ModTime().Before(cutoff)
+			}
+		}
+		if tooMany || tooOld {
+			if err := os.Remove(full); err != nil {
+				return fmt.Errorf("remove old log %q: %w", full, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Reopen closes and reopens the active file at the same path, without
+// rotating it. Wire this into a SIGHUP handler so external log
+// shippers that rotate files out from under the process (e.g.
+// logrotate) can signal gokart to pick the new file back up.
+func (w *RotatingWriter) Reopen() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("close log file %q before reopen: %w", w.path, err)
+	}
+	return w.openCurrent()
+}
+
+// Close closes the currently open file.
+func (w *RotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.file.Close()
+}
+
+// gzipFile compresses path to path+".gz" and removes the original.
+func gzipFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path + ".gz")
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		gz.Close()
+		dst.Close()
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		dst.Close()
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
@@ -2,14 +2,320 @@ package gokart
 
 import (
 	"context"
+	"crypto/crc32"
 	"database/sql"
 	"fmt"
 	"io/fs"
+	"sync"
 
 	"github.com/pressly/goose/v3"
 )
 
+// Driver applies migrations for one database, independent of any global
+// package state. This is what lets a process run a Postgres Migrator and
+// a SQLite Migrator side by side without one clobbering the other's
+// dialect/table/FS configuration, unlike the old goose.SetDialect-based
+// global functions below.
+type Driver interface {
+	Up(ctx context.Context, db *sql.DB, dir string, fsys fs.FS) error
+	Down(ctx context.Context, db *sql.DB, dir string, fsys fs.FS) error
+	Steps(ctx context.Context, db *sql.DB, dir string, fsys fs.FS, n int) error
+	Force(ctx context.Context, db *sql.DB, version int64) error
+	Version(ctx context.Context, db *sql.DB) (int64, error)
+	Drop(ctx context.Context, db *sql.DB) error
+}
+
+// Migrator runs migrations for one database using a pluggable Driver
+// (goose by default; see NewGooseDriver). Dialect selects the dialect-aware
+// advisory lock so concurrent deploys against the same database don't
+// race, independent of which Driver is in use.
+type Migrator struct {
+	db      *sql.DB
+	dialect string
+	table   string
+	dir     string
+	fsys    fs.FS
+	driver  Driver
+}
+
+// MigratorOption configures a Migrator.
+type MigratorOption func(*Migrator)
+
+// WithDriver selects the migration backend. Default: NewGooseDriver(table, dialect).
+func WithDriver(d Driver) MigratorOption {
+	return func(m *Migrator) { m.driver = d }
+}
+
+// WithTable sets the migrations tracking table name. Default: "goose_db_version".
+func WithTable(table string) MigratorOption {
+	return func(m *Migrator) { m.table = table }
+}
+
+// WithDir sets the directory migrations are read from. Default: "migrations".
+func WithDir(dir string) MigratorOption {
+	return func(m *Migrator) { m.dir = dir }
+}
+
+// WithFS sets an embedded filesystem to read migrations from, instead of
+// the OS filesystem.
+func WithFS(fsys fs.FS) MigratorOption {
+	return func(m *Migrator) { m.fsys = fsys }
+}
+
+// NewMigrator creates a Migrator for db using dialect ("postgres",
+// "sqlite3", or "mysql") to pick the advisory lock strategy.
+//
+// Example:
+//
+//	m := gokart.NewMigrator(db, "postgres", gokart.WithDir("migrations"))
+//	if err := m.Up(ctx); err != nil {
+//	    log.Fatal(err)
+//	}
+func NewMigrator(db *sql.DB, dialect string, opts ...MigratorOption) *Migrator {
+	m := &Migrator{
+		db:      db,
+		dialect: dialect,
+		table:   "goose_db_version",
+		dir:     "migrations",
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.driver == nil {
+		m.driver = NewGooseDriver(m.table, m.dialect)
+	}
+	return m
+}
+
+// Up applies all pending migrations, holding a dialect-aware advisory
+// lock for the duration so concurrent deploys don't race.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.withLock(ctx, func() error {
+		return m.driver.Up(ctx, m.db, m.dir, m.fsys)
+	})
+}
+
+// Down rolls back the most recently applied migration.
+func (m *Migrator) Down(ctx context.Context) error {
+	return m.withLock(ctx, func() error {
+		return m.driver.Down(ctx, m.db, m.dir, m.fsys)
+	})
+}
+
+// Steps applies n migrations forward (n > 0) or rolls back |n| (n < 0).
+func (m *Migrator) Steps(ctx context.Context, n int) error {
+	return m.withLock(ctx, func() error {
+		return m.driver.Steps(ctx, m.db, m.dir, m.fsys, n)
+	})
+}
+
+// Force sets the recorded version without running any migration, for
+// recovering from a migration that partially applied out of band.
+func (m *Migrator) Force(ctx context.Context, version int64) error {
+	return m.withLock(ctx, func() error {
+		return m.driver.Force(ctx, m.db, version)
+	})
+}
+
+// Version returns the currently applied migration version.
+func (m *Migrator) Version(ctx context.Context) (int64, error) {
+	return m.driver.Version(ctx, m.db)
+}
+
+// Drop removes all tables managed by the migrations, including the
+// tracking table itself.
+func (m *Migrator) Drop(ctx context.Context) error {
+	return m.withLock(ctx, func() error {
+		return m.driver.Drop(ctx, m.db)
+	})
+}
+
+// withLock holds a dialect-appropriate advisory lock for the duration of
+// fn: pg_advisory_lock on Postgres, GET_LOCK on MySQL, and a BEGIN
+// IMMEDIATE transaction on SQLite. Unknown dialects run fn unlocked.
+func (m *Migrator) withLock(ctx context.Context, fn func() error) error {
+	lockKey := int32(crc32.ChecksumIEEE([]byte(m.table)))
+
+	switch m.dialect {
+	case "postgres":
+		conn, err := m.db.Conn(ctx)
+		if err != nil {
+			return fmt.Errorf("acquire migration lock connection: %w", err)
+		}
+		defer conn.Close()
+
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+			return fmt.Errorf("acquire postgres advisory lock: %w", err)
+		}
+		defer conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", lockKey)
+
+		return fn()
+
+	case "mysql":
+		conn, err := m.db.Conn(ctx)
+		if err != nil {
+			return fmt.Errorf("acquire migration lock connection: %w", err)
+		}
+		defer conn.Close()
+
+		lockName := fmt.Sprintf("gokart_migrate_%s", m.table)
+		var acquired int
+		if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 30)", lockName).Scan(&acquired); err != nil {
+			return fmt.Errorf("acquire mysql lock: %w", err)
+		}
+		if acquired != 1 {
+			return fmt.Errorf("acquire mysql lock %q: timed out", lockName)
+		}
+		defer conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", lockName)
+
+		return fn()
+
+	case "sqlite3", "sqlite":
+		conn, err := m.db.Conn(ctx)
+		if err != nil {
+			return fmt.Errorf("acquire migration lock connection: %w", err)
+		}
+		defer conn.Close()
+
+		if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+			return fmt.Errorf("acquire sqlite lock: %w", err)
+		}
+
+		if err := fn(); err != nil {
+			conn.ExecContext(ctx, "ROLLBACK")
+			return err
+		}
+		_, err = conn.ExecContext(ctx, "COMMIT")
+		return err
+
+	default:
+		return fn()
+	}
+}
+
+// --- goose driver ---
+
+// gooseMu serializes every call into goose. goose keeps its dialect,
+// table name, and base FS as package-level global state (not per-db), so
+// even though gooseDriver scopes that configuration to one instance, two
+// gooseDrivers calling into goose concurrently would still race setting
+// those globals out from under each other; gooseMu makes "configure
+// globals, then run" atomic across all gooseDrivers in the process.
+var gooseMu sync.Mutex
+
+// gooseDriver implements Driver on top of pressly/goose, scoping the
+// dialect/table/FS that goose otherwise keeps as global package state to
+// one instance, so multiple Migrators (e.g. Postgres and SQLite) can run
+// in the same process safely.
+type gooseDriver struct {
+	table   string
+	dialect string
+}
+
+// NewGooseDriver returns a Driver backed by goose, tracking versions in
+// the given table and using dialect ("postgres", "sqlite3", or "mysql")
+// to generate dialect-correct version-table SQL.
+func NewGooseDriver(table, dialect string) Driver {
+	return &gooseDriver{table: table, dialect: dialect}
+}
+
+// apply points goose's global dialect/table/FS state at this driver's
+// configuration. Callers must hold gooseMu for the duration of apply and
+// whatever goose call follows it.
+func (d *gooseDriver) apply(db *sql.DB, dir string, fsys fs.FS) error {
+	if d.dialect != "" {
+		if err := goose.SetDialect(d.dialect); err != nil {
+			return fmt.Errorf("set goose dialect %q: %w", d.dialect, err)
+		}
+	}
+	goose.SetTableName(d.table)
+	if fsys != nil {
+		goose.SetBaseFS(fsys)
+	} else {
+		goose.SetBaseFS(nil)
+	}
+	return nil
+}
+
+func (d *gooseDriver) Up(ctx context.Context, db *sql.DB, dir string, fsys fs.FS) error {
+	gooseMu.Lock()
+	defer gooseMu.Unlock()
+
+	if err := d.apply(db, dir, fsys); err != nil {
+		return err
+	}
+	return goose.UpContext(ctx, db, dir)
+}
+
+func (d *gooseDriver) Down(ctx context.Context, db *sql.DB, dir string, fsys fs.FS) error {
+	gooseMu.Lock()
+	defer gooseMu.Unlock()
+
+	if err := d.apply(db, dir, fsys); err != nil {
+		return err
+	}
+	return goose.DownContext(ctx, db, dir)
+}
+
+func (d *gooseDriver) Steps(ctx context.Context, db *sql.DB, dir string, fsys fs.FS, n int) error {
+	gooseMu.Lock()
+	defer gooseMu.Unlock()
+
+	if err := d.apply(db, dir, fsys); err != nil {
+		return err
+	}
+	if n >= 0 {
+		for i := 0; i < n; i++ {
+			if err := goose.UpByOneContext(ctx, db, dir); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for i := 0; i < -n; i++ {
+		if err := goose.DownContext(ctx, db, dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *gooseDriver) Force(ctx context.Context, db *sql.DB, version int64) error {
+	gooseMu.Lock()
+	defer gooseMu.Unlock()
+
+	if err := d.apply(db, "", nil); err != nil {
+		return err
+	}
+	return goose.SetVersion(db, version)
+}
+
+func (d *gooseDriver) Version(ctx context.Context, db *sql.DB) (int64, error) {
+	gooseMu.Lock()
+	defer gooseMu.Unlock()
+
+	if err := d.apply(db, "", nil); err != nil {
+		return 0, err
+	}
+	return goose.GetDBVersionContext(ctx, db)
+}
+
+func (d *gooseDriver) Drop(ctx context.Context, db *sql.DB) error {
+	gooseMu.Lock()
+	defer gooseMu.Unlock()
+
+	if err := d.apply(db, "", nil); err != nil {
+		return err
+	}
+	return goose.ResetContext(ctx, db, "")
+}
+
+// --- legacy package-level API (kept for backward compatibility) ---
+
 // MigrateConfig configures database migrations.
+//
+// Deprecated: construct a Migrator via NewMigrator instead, which does
+// not rely on goose's global dialect/table/FS state.
 type MigrateConfig struct {
 	// Dir is the directory containing migration files.
 	// Default: "migrations"
@@ -43,164 +349,118 @@ func DefaultMigrateConfig() MigrateConfig {
 	}
 }
 
+func (cfg MigrateConfig) migrator(db *sql.DB) *Migrator {
+	dir := cfg.Dir
+	if dir == "" {
+		dir = "migrations"
+	}
+	table := cfg.Table
+	if table == "" {
+		table = "goose_db_version"
+	}
+	return NewMigrator(db, cfg.Dialect, WithDir(dir), WithTable(table), WithFS(cfg.FS))
+}
+
 // Migrate runs all pending migrations.
 //
-// Example with file-based migrations:
-//
-//	db, _ := gokart.OpenPostgres(ctx, url)
-//	err := gokart.Migrate(ctx, db.Config().ConnConfig.Database, gokart.MigrateConfig{
-//	    Dir:     "migrations",
-//	    Dialect: "postgres",
-//	})
-//
-// Example with embedded migrations:
-//
-//	//go:embed migrations/*.sql
-//	var migrations embed.FS
-//
-//	err := gokart.Migrate(ctx, db, gokart.MigrateConfig{
-//	    FS:      migrations,
-//	    Dir:     "migrations",
-//	    Dialect: "postgres",
-//	})
+// Deprecated: use NewMigrator(db, dialect, ...).Up(ctx) instead.
 func Migrate(ctx context.Context, db *sql.DB, cfg MigrateConfig) error {
-	if err := setupMigration(&cfg); err != nil {
-		return err
-	}
-
-	if err := goose.UpContext(ctx, db, cfg.Dir); err != nil {
+	if err := cfg.migrator(db).Up(ctx); err != nil {
 		return fmt.Errorf("migration failed: %w", err)
 	}
-
 	return nil
 }
 
 // MigrateUp runs all pending migrations.
+//
+// Deprecated: use NewMigrator(db, dialect, ...).Up(ctx) instead.
 func MigrateUp(ctx context.Context, db *sql.DB, cfg MigrateConfig) error {
 	return Migrate(ctx, db, cfg)
 }
 
 // MigrateDown rolls back the last migration.
+//
+// Deprecated: use NewMigrator(db, dialect, ...).Down(ctx) instead.
 func MigrateDown(ctx context.Context, db *sql.DB, cfg MigrateConfig) error {
-	if err := setupMigration(&cfg); err != nil {
-		return err
-	}
-
-	if err := goose.DownContext(ctx, db, cfg.Dir); err != nil {
+	if err := cfg.migrator(db).Down(ctx); err != nil {
 		return fmt.Errorf("rollback failed: %w", err)
 	}
-
 	return nil
 }
 
 // MigrateDownTo rolls back to a specific version.
+//
+// Deprecated: use NewMigrator(db, dialect, ...).Force/Steps instead.
 func MigrateDownTo(ctx context.Context, db *sql.DB, cfg MigrateConfig, version int64) error {
-	if err := setupMigration(&cfg); err != nil {
-		return err
-	}
-
-	if err := goose.DownToContext(ctx, db, cfg.Dir, version); err != nil {
-		return fmt.Errorf("rollback to version %d failed: %w", version, err)
+	m := cfg.migrator(db)
+	for {
+		current, err := m.Version(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get version: %w", err)
+		}
+		if current <= version {
+			return nil
+		}
+		if err := m.Down(ctx); err != nil {
+			return fmt.Errorf("rollback to version %d failed: %w", version, err)
+		}
 	}
-
-	return nil
 }
 
 // MigrateReset rolls back all migrations.
+//
+// Deprecated: use NewMigrator(db, dialect, ...).Drop(ctx) instead.
 func MigrateReset(ctx context.Context, db *sql.DB, cfg MigrateConfig) error {
 	return MigrateDownTo(ctx, db, cfg, 0)
 }
 
 // MigrateStatus prints the status of all migrations.
+//
+// Deprecated: use NewMigrator(db, dialect, ...).Version(ctx) instead.
 func MigrateStatus(ctx context.Context, db *sql.DB, cfg MigrateConfig) error {
-	if err := setupMigration(&cfg); err != nil {
-		return err
-	}
-
-	if err := goose.StatusContext(ctx, db, cfg.Dir); err != nil {
+	version, err := cfg.migrator(db).Version(ctx)
+	if err != nil {
 		return fmt.Errorf("status failed: %w", err)
 	}
-
+	fmt.Printf("current version: %d\n", version)
 	return nil
 }
 
 // MigrateVersion returns the current migration version.
+//
+// Deprecated: use NewMigrator(db, dialect, ...).Version(ctx) instead.
 func MigrateVersion(ctx context.Context, db *sql.DB, cfg MigrateConfig) (int64, error) {
-	if err := setupMigration(&cfg); err != nil {
-		return 0, err
-	}
-
-	version, err := goose.GetDBVersionContext(ctx, db)
+	version, err := cfg.migrator(db).Version(ctx)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get version: %w", err)
 	}
-
 	return version, nil
 }
 
 // MigrateCreate creates a new migration file.
-//
-// Example:
-//
-//	err := gokart.MigrateCreate("migrations", "add_users_table", "sql")
 func MigrateCreate(dir, name, migrationType string) error {
 	if dir == "" {
 		dir = "migrations"
 	}
-
 	if migrationType == "" {
 		migrationType = "sql"
 	}
-
 	if err := goose.Create(nil, dir, name, migrationType); err != nil {
 		return fmt.Errorf("failed to create migration: %w", err)
 	}
-
 	return nil
 }
 
 // PostgresMigrate is a convenience function for PostgreSQL migrations.
 //
-// Example:
-//
-//	pool, _ := gokart.OpenPostgres(ctx, url)
-//	db := stdlib.OpenDBFromPool(pool)
-//	err := gokart.PostgresMigrate(ctx, db, "migrations")
+// Deprecated: use NewMigrator(db, "postgres", ...).Up(ctx) instead.
 func PostgresMigrate(ctx context.Context, db *sql.DB, dir string) error {
-	return Migrate(ctx, db, MigrateConfig{
-		Dir:     dir,
-		Dialect: "postgres",
-	})
+	return NewMigrator(db, "postgres", WithDir(dir)).Up(ctx)
 }
 
 // SQLiteMigrate is a convenience function for SQLite migrations.
 //
-// Example:
-//
-//	db, _ := gokart.OpenSQLite("app.db")
-//	err := gokart.SQLiteMigrate(ctx, db, "migrations")
+// Deprecated: use NewMigrator(db, "sqlite3", ...).Up(ctx) instead.
 func SQLiteMigrate(ctx context.Context, db *sql.DB, dir string) error {
-	return Migrate(ctx, db, MigrateConfig{
-		Dir:     dir,
-		Dialect: "sqlite3",
-	})
-}
-
-// setupMigration applies common configuration for migration operations.
-func setupMigration(cfg *MigrateConfig) error {
-	if cfg.Dir == "" {
-		cfg.Dir = "migrations"
-	}
-	if cfg.Table != "" {
-		goose.SetTableName(cfg.Table)
-	}
-	if cfg.Dialect != "" {
-		if err := goose.SetDialect(cfg.Dialect); err != nil {
-			return fmt.Errorf("invalid dialect: %w", err)
-		}
-	}
-	if cfg.FS != nil {
-		goose.SetBaseFS(cfg.FS)
-	}
-	return nil
+	return NewMigrator(db, "sqlite3", WithDir(dir)).Up(ctx)
 }
@@ -0,0 +1,123 @@
+package gokart
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/database/mysql"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// golangMigrateDriver implements Driver on top of golang-migrate/migrate
+// v4, as an alternative backend to goose for teams already standardized
+// on golang-migrate's CLI and migration file naming
+// ("NNNNNN_name.up.sql").
+type golangMigrateDriver struct {
+	dialect string
+}
+
+// NewGolangMigrateDriver returns a Driver backed by golang-migrate/migrate
+// v4, using dialect ("postgres", "mysql", or "sqlite3") to pick the
+// database driver.
+func NewGolangMigrateDriver(dialect string) Driver {
+	return &golangMigrateDriver{dialect: dialect}
+}
+
+func (d *golangMigrateDriver) open(db *sql.DB, dir string, fsys fs.FS) (*migrate.Migrate, error) {
+	if fsys == nil {
+		fsys = os.DirFS(dir)
+		dir = "."
+	}
+
+	source, err := iofs.New(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("create golang-migrate source: %w", err)
+	}
+
+	var dbDriver database.Driver
+	switch d.dialect {
+	case "postgres":
+		dbDriver, err = postgres.WithInstance(db, &postgres.Config{})
+	case "mysql":
+		dbDriver, err = mysql.WithInstance(db, &mysql.Config{})
+	case "sqlite3", "sqlite":
+		dbDriver, err = sqlite3.WithInstance(db, &sqlite3.Config{})
+	default:
+		return nil, fmt.Errorf("golang-migrate driver: unsupported dialect %q", d.dialect)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create golang-migrate database driver: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", source, d.dialect, dbDriver)
+	if err != nil {
+		return nil, fmt.Errorf("create golang-migrate instance: %w", err)
+	}
+	return m, nil
+}
+
+func (d *golangMigrateDriver) Up(ctx context.Context, db *sql.DB, dir string, fsys fs.FS) error {
+	m, err := d.open(db, dir, fsys)
+	if err != nil {
+		return err
+	}
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+func (d *golangMigrateDriver) Down(ctx context.Context, db *sql.DB, dir string, fsys fs.FS) error {
+	m, err := d.open(db, dir, fsys)
+	if err != nil {
+		return err
+	}
+	return m.Steps(-1)
+}
+
+func (d *golangMigrateDriver) Steps(ctx context.Context, db *sql.DB, dir string, fsys fs.FS, n int) error {
+	m, err := d.open(db, dir, fsys)
+	if err != nil {
+		return err
+	}
+	if err := m.Steps(n); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+func (d *golangMigrateDriver) Force(ctx context.Context, db *sql.DB, version int64) error {
+	m, err := d.open(db, "migrations", nil)
+	if err != nil {
+		return err
+	}
+	return m.Force(int(version))
+}
+
+func (d *golangMigrateDriver) Version(ctx context.Context, db *sql.DB) (int64, error) {
+	m, err := d.open(db, "migrations", nil)
+	if err != nil {
+		return 0, err
+	}
+	version, _, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, nil
+	}
+	return int64(version), err
+}
+
+func (d *golangMigrateDriver) Drop(ctx context.Context, db *sql.DB) error {
+	m, err := d.open(db, "migrations", nil)
+	if err != nil {
+		return err
+	}
+	return m.Drop()
+}
@@ -0,0 +1,41 @@
+package gokart_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/dotcommander/gokart"
+	_ "modernc.org/sqlite"
+)
+
+// TestMigratorUsesConfiguredDialect is the regression case: NewMigrator
+// used to only pass the table name into NewGooseDriver, never the
+// dialect, so goose fell back to its postgres default and tried to
+// create the version tracking table with postgres-only SQL against a
+// sqlite connection.
+func TestMigratorUsesConfiguredDialect(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer db.Close()
+
+	m := gokart.NewMigrator(db, "sqlite3", gokart.WithDir(t.TempDir()))
+
+	if err := m.Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+
+	version, err := m.Version(ctx)
+	if err != nil {
+		t.Fatalf("Version: %v", err)
+	}
+	if version != 0 {
+		t.Fatalf("expected version 0 with no migrations, got %d", version)
+	}
+}
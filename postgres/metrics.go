@@ -0,0 +1,182 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// metricsPollInterval is how often RegisterMetrics samples pool.Stat().
+const metricsPollInterval = 5 * time.Second
+
+// RegisterMetrics registers gauges and a histogram on reg that track
+// pool's connection usage, sampled from pool.Stat() every 5 seconds
+// until ctx is canceled. Call it once per pool, typically right after
+// Open/OpenWithConfig.
+//
+// Example:
+//
+//	pool, err := postgres.Open(ctx, url)
+//	postgres.RegisterMetrics(ctx, pool, prometheus.DefaultRegisterer)
+func RegisterMetrics(ctx context.Context, pool *pgxpool.Pool, reg prometheus.Registerer) error {
+	totalConns := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "postgres",
+		Name:      "total_conns",
+		Help:      "Total connections currently open in the pool.",
+	})
+	idleConns := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "postgres",
+		Name:      "idle_conns",
+		Help:      "Connections currently idle in the pool.",
+	})
+	constructingConns := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "postgres",
+		Name:      "constructing_conns",
+		Help:      "Connections currently being established.",
+	})
+	acquireCount := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "postgres",
+		Name:      "acquire_count",
+		Help:      "Cumulative number of successful connection acquires.",
+	})
+	canceledAcquireCount := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "postgres",
+		Name:      "canceled_acquire_count",
+		Help:      "Cumulative number of acquires canceled by their context.",
+	})
+	acquireDuration := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "postgres",
+		Name:      "acquire_duration_seconds",
+		Help:      "Time spent acquiring a connection from the pool.",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	collectors := []prometheus.Collector{
+		totalConns, idleConns, constructingConns,
+		acquireCount, canceledAcquireCount, acquireDuration,
+	}
+	for _, c := range collectors {
+		if err := reg.Register(c); err != nil {
+			return err
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(metricsPollInterval)
+		defer ticker.Stop()
+
+		var lastAcquireCount int64
+		var lastAcquireDuration time.Duration
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				stat := pool.Stat()
+
+				totalConns.Set(float64(stat.TotalConns()))
+				idleConns.Set(float64(stat.IdleConns()))
+				constructingConns.Set(float64(stat.ConstructingConns()))
+				acquireCount.Set(float64(stat.AcquireCount()))
+				canceledAcquireCount.Set(float64(stat.CanceledAcquireCount()))
+
+				if delta := stat.AcquireCount() - lastAcquireCount; delta > 0 {
+					durationDelta := stat.AcquireDuration() - lastAcquireDuration
+					acquireDuration.Observe(durationDelta.Seconds() / float64(delta))
+				}
+				lastAcquireCount = stat.AcquireCount()
+				lastAcquireDuration = stat.AcquireDuration()
+			}
+		}
+	}()
+
+	return nil
+}
+
+// MetricsTracer is a pgx.QueryTracer that counts and times every query,
+// plus (once started against a live pool via Start) polls pool-level
+// gauges in the background. Unlike RegisterMetrics, it's built to be
+// assigned straight to Config.Tracer (or combined with NewLogTracer via
+// MultiTracer), so it can observe per-query timings as they happen
+// rather than only the pool-wide counters pool.Stat() exposes.
+type MetricsTracer struct {
+	queriesTotal  prometheus.Counter
+	queryDuration prometheus.Histogram
+	acquiredConns prometheus.Gauge
+	idleConns     prometheus.Gauge
+}
+
+type metricsTraceCtxKey struct{}
+
+// NewMetricsTracer registers pg_queries_total, pg_query_duration_seconds,
+// pg_pool_acquired_conns, and pg_pool_idle_conns on reg and returns a
+// tracer that keeps them updated. Call Start once the pool exists to
+// begin polling pool.Stat() for the two gauges.
+//
+// Example:
+//
+//	metrics, err := postgres.NewMetricsTracer(prometheus.DefaultRegisterer)
+//	pool, err := postgres.OpenWithConfig(ctx, postgres.Config{URL: url, Tracer: metrics})
+//	metrics.Start(ctx, pool)
+func NewMetricsTracer(reg prometheus.Registerer) (*MetricsTracer, error) {
+	t := &MetricsTracer{
+		queriesTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "pg_queries_total",
+			Help: "Total number of queries executed.",
+		}),
+		queryDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "pg_query_duration_seconds",
+			Help:    "Query execution duration.",
+			Buckets: prometheus.DefBuckets,
+		}),
+		acquiredConns: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "pg_pool_acquired_conns",
+			Help: "Connections currently acquired from the pool.",
+		}),
+		idleConns: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "pg_pool_idle_conns",
+			Help: "Connections currently idle in the pool.",
+		}),
+	}
+
+	for _, c := range []prometheus.Collector{t.queriesTotal, t.queryDuration, t.acquiredConns, t.idleConns} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// Start begins polling pool.Stat() every 5 seconds to update
+// pg_pool_acquired_conns and pg_pool_idle_conns, until ctx is canceled.
+func (t *MetricsTracer) Start(ctx context.Context, pool *pgxpool.Pool) {
+	go func() {
+		ticker := time.NewTicker(metricsPollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				stat := pool.Stat()
+				t.acquiredConns.Set(float64(stat.TotalConns() - stat.IdleConns()))
+				t.idleConns.Set(float64(stat.IdleConns()))
+			}
+		}
+	}()
+}
+
+func (t *MetricsTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, metricsTraceCtxKey{}, time.Now())
+}
+
+func (t *MetricsTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryEndData) {
+	start, _ := ctx.Value(metricsTraceCtxKey{}).(time.Time)
+	t.queriesTotal.Inc()
+	t.queryDuration.Observe(time.Since(start).Seconds())
+}
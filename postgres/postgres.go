@@ -4,6 +4,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -35,6 +36,26 @@ type Config struct {
 	// HealthCheckPeriod is how often to check connection health.
 	// Default: 1 minute
 	HealthCheckPeriod time.Duration
+
+	// Logger, if set, installs a pgx.QueryTracer that logs every query,
+	// batch, and connection attempt through it via WithTracer. Nil
+	// disables tracing.
+	Logger *slog.Logger
+
+	// SlowQueryThreshold logs queries and batches taking at least this
+	// long at Warn instead of Debug. Only used when Logger is set.
+	SlowQueryThreshold time.Duration
+
+	// RedactArgs lists 1-based positional parameter indexes to redact
+	// from logged query args. Only used when Logger is set.
+	RedactArgs []int
+
+	// Tracer, if set, is installed on the pool directly, taking priority
+	// over Logger/SlowQueryThreshold/RedactArgs. Use this to plug in
+	// NewLogTracer, NewMetricsTracer, or both via MultiTracer, or any
+	// other pgx.QueryTracer (pgx also checks it for BatchTracer and
+	// ConnectTracer via type assertion).
+	Tracer pgx.QueryTracer
 }
 
 // DefaultConfig returns production-ready defaults.
@@ -95,6 +116,15 @@ func OpenWithConfig(ctx context.Context, cfg Config) (*pgxpool.Pool, error) {
 	if cfg.HealthCheckPeriod > 0 {
 		poolCfg.HealthCheckPeriod = cfg.HealthCheckPeriod
 	}
+	switch {
+	case cfg.Tracer != nil:
+		poolCfg.ConnConfig.Tracer = cfg.Tracer
+	case cfg.Logger != nil:
+		poolCfg = WithTracer(poolCfg, cfg.Logger, TracerOptions{
+			SlowQueryThreshold: cfg.SlowQueryThreshold,
+			RedactArgs:         cfg.RedactArgs,
+		})
+	}
 
 	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
 	if err != nil {
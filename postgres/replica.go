@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ReplicaPool routes SELECT statements to a read replica and everything
+// else to the primary, for a simple read/write split without a
+// connection proxy in front of Postgres. The routing is a plain prefix
+// check on the SQL text, not a parser — statements that begin with a
+// CTE ("WITH ... SELECT ...") or that mutate data via a function call
+// inside a SELECT won't be routed the way their semantics might suggest.
+type ReplicaPool struct {
+	Primary *pgxpool.Pool
+	Replica *pgxpool.Pool
+}
+
+// OpenReadReplica opens both primaryURL and replicaURL with
+// DefaultConfig and returns a ReplicaPool wrapping them.
+//
+// Example:
+//
+//	db, err := postgres.OpenReadReplica(ctx, primaryURL, replicaURL)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer db.Close()
+//
+//	var name string
+//	err = db.QueryRow(ctx, "SELECT name FROM users WHERE id = $1", 1).Scan(&name) // -> replica
+//	_, err = db.Exec(ctx, "UPDATE users SET name = $1 WHERE id = $2", "x", 1)     // -> primary
+func OpenReadReplica(ctx context.Context, primaryURL, replicaURL string) (*ReplicaPool, error) {
+	primary, err := Open(ctx, primaryURL)
+	if err != nil {
+		return nil, fmt.Errorf("open primary: %w", err)
+	}
+
+	replica, err := Open(ctx, replicaURL)
+	if err != nil {
+		primary.Close()
+		return nil, fmt.Errorf("open replica: %w", err)
+	}
+
+	return &ReplicaPool{Primary: primary, Replica: replica}, nil
+}
+
+// pick returns Replica for a SELECT statement and Primary otherwise.
+func (p *ReplicaPool) pick(sql string) *pgxpool.Pool {
+	trimmed := strings.TrimSpace(sql)
+	if len(trimmed) >= 6 && strings.EqualFold(trimmed[:6], "select") {
+		return p.Replica
+	}
+	return p.Primary
+}
+
+// Query routes to Replica for SELECT statements, Primary otherwise.
+func (p *ReplicaPool) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return p.pick(sql).Query(ctx, sql, args...)
+}
+
+// QueryRow routes to Replica for SELECT statements, Primary otherwise.
+func (p *ReplicaPool) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return p.pick(sql).QueryRow(ctx, sql, args...)
+}
+
+// Exec always runs against Primary, since it's only ever used for
+// statements that mutate data.
+func (p *ReplicaPool) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return p.Primary.Exec(ctx, sql, args...)
+}
+
+// Close closes both the primary and replica pools.
+func (p *ReplicaPool) Close() {
+	p.Primary.Close()
+	p.Replica.Close()
+}
@@ -0,0 +1,243 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TracerOptions configures WithTracer's logging.
+type TracerOptions struct {
+	// SlowQueryThreshold logs queries taking at least this long at Warn
+	// instead of Debug. Zero disables slow-query escalation.
+	SlowQueryThreshold time.Duration
+
+	// RedactArgs lists 1-based positional parameter indexes whose values
+	// are replaced with "[redacted]" in logged records, for SQL text that
+	// carries secrets (passwords, tokens) as bind parameters.
+	RedactArgs []int
+}
+
+type traceQueryCtxKey struct{}
+type traceBatchCtxKey struct{}
+type traceConnectCtxKey struct{}
+
+type queryTraceData struct {
+	sql   string
+	args  []any
+	start time.Time
+}
+
+type batchTraceData struct {
+	start time.Time
+	count int
+}
+
+type connectTraceData struct {
+	start time.Time
+}
+
+// queryTracer implements pgx.QueryTracer, pgx.BatchTracer, and
+// pgx.ConnectTracer, logging every query/batch/connect through a
+// *slog.Logger so it works with both of logger.New's text and JSON
+// handlers.
+type queryTracer struct {
+	log  *slog.Logger
+	opts TracerOptions
+}
+
+// WithTracer installs a queryTracer on poolCfg.ConnConfig, returning
+// poolCfg for chaining. Pass the result to pgxpool.NewWithConfig.
+//
+// Example:
+//
+//	poolCfg, _ := pgxpool.ParseConfig(url)
+//	poolCfg = postgres.WithTracer(poolCfg, logger.NewDefault(), postgres.TracerOptions{
+//	    SlowQueryThreshold: 200 * time.Millisecond,
+//	    RedactArgs:         []int{1},
+//	})
+//	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+func WithTracer(poolCfg *pgxpool.Config, log *slog.Logger, opts TracerOptions) *pgxpool.Config {
+	poolCfg.ConnConfig.Tracer = NewLogTracer(log, opts.SlowQueryThreshold, opts.RedactArgs...)
+	return poolCfg
+}
+
+// NewLogTracer returns a pgx.QueryTracer/BatchTracer/ConnectTracer that
+// logs through log, escalating to Warn once a query or batch's duration
+// reaches slowThreshold (zero disables the escalation). Assign it
+// directly to Config.Tracer, or combine it with NewMetricsTracer via
+// MultiTracer.
+//
+// Example:
+//
+//	pool, err := postgres.OpenWithConfig(ctx, postgres.Config{
+//	    URL:    url,
+//	    Tracer: postgres.NewLogTracer(log, 200*time.Millisecond, 1),
+//	})
+func NewLogTracer(log *slog.Logger, slowThreshold time.Duration, redactArgs ...int) pgx.QueryTracer {
+	return &queryTracer{log: log, opts: TracerOptions{SlowQueryThreshold: slowThreshold, RedactArgs: redactArgs}}
+}
+
+// MultiTracer fans a single pgx.QueryTracer/BatchTracer/ConnectTracer
+// call out to every tracer in tracers, in order, so e.g. NewLogTracer
+// and NewMetricsTracer can both be installed as Config.Tracer at once.
+func MultiTracer(tracers ...pgx.QueryTracer) pgx.QueryTracer {
+	return multiTracer(tracers)
+}
+
+type multiTracer []pgx.QueryTracer
+
+func (m multiTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	for _, t := range m {
+		ctx = t.TraceQueryStart(ctx, conn, data)
+	}
+	return ctx
+}
+
+func (m multiTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	for _, t := range m {
+		t.TraceQueryEnd(ctx, conn, data)
+	}
+}
+
+func (m multiTracer) TraceBatchStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchStartData) context.Context {
+	for _, t := range m {
+		if bt, ok := t.(pgx.BatchTracer); ok {
+			ctx = bt.TraceBatchStart(ctx, conn, data)
+		}
+	}
+	return ctx
+}
+
+func (m multiTracer) TraceBatchQuery(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchQueryData) {
+	for _, t := range m {
+		if bt, ok := t.(pgx.BatchTracer); ok {
+			bt.TraceBatchQuery(ctx, conn, data)
+		}
+	}
+}
+
+func (m multiTracer) TraceBatchEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchEndData) {
+	for _, t := range m {
+		if bt, ok := t.(pgx.BatchTracer); ok {
+			bt.TraceBatchEnd(ctx, conn, data)
+		}
+	}
+}
+
+func (m multiTracer) TraceConnectStart(ctx context.Context, data pgx.TraceConnectStartData) context.Context {
+	for _, t := range m {
+		if ct, ok := t.(pgx.ConnectTracer); ok {
+			ctx = ct.TraceConnectStart(ctx, data)
+		}
+	}
+	return ctx
+}
+
+func (m multiTracer) TraceConnectEnd(ctx context.Context, data pgx.TraceConnectEndData) {
+	for _, t := range m {
+		if ct, ok := t.(pgx.ConnectTracer); ok {
+			ct.TraceConnectEnd(ctx, data)
+		}
+	}
+}
+
+func (t *queryTracer) redact(args []any) []any {
+	if len(t.opts.RedactArgs) == 0 {
+		return args
+	}
+	redacted := append([]any(nil), args...)
+	for _, idx := range t.opts.RedactArgs {
+		if idx >= 1 && idx <= len(redacted) {
+			redacted[idx-1] = "[redacted]"
+		}
+	}
+	return redacted
+}
+
+func (t *queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, traceQueryCtxKey{}, queryTraceData{
+		sql:   data.SQL,
+		args:  data.Args,
+		start: time.Now(),
+	})
+}
+
+func (t *queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	trace, _ := ctx.Value(traceQueryCtxKey{}).(queryTraceData)
+	duration := time.Since(trace.start)
+
+	attrs := []any{
+		"sql", trace.sql,
+		"args", t.redact(trace.args),
+		"arg_count", len(trace.args),
+		"duration_ms", duration.Milliseconds(),
+	}
+	if data.Err != nil {
+		t.log.Error("postgres: query failed", append(attrs, "error", data.Err)...)
+		return
+	}
+
+	attrs = append(attrs, "rows_affected", data.CommandTag.RowsAffected())
+	switch {
+	case t.opts.SlowQueryThreshold > 0 && duration >= t.opts.SlowQueryThreshold:
+		t.log.Warn("postgres: slow query", attrs...)
+	default:
+		t.log.Debug("postgres: query", attrs...)
+	}
+}
+
+func (t *queryTracer) TraceBatchStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchStartData) context.Context {
+	count := 0
+	if data.Batch != nil {
+		count = data.Batch.Len()
+	}
+	return context.WithValue(ctx, traceBatchCtxKey{}, &batchTraceData{start: time.Now(), count: count})
+}
+
+func (t *queryTracer) TraceBatchQuery(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchQueryData) {
+	attrs := []any{
+		"sql", data.SQL,
+		"args", t.redact(data.Args),
+	}
+	if data.Err != nil {
+		t.log.Error("postgres: batch query failed", append(attrs, "error", data.Err)...)
+		return
+	}
+	t.log.Debug("postgres: batch query", append(attrs, "rows_affected", data.CommandTag.RowsAffected())...)
+}
+
+func (t *queryTracer) TraceBatchEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchEndData) {
+	trace, _ := ctx.Value(traceBatchCtxKey{}).(*batchTraceData)
+	duration := time.Since(trace.start)
+
+	attrs := []any{"batch_size", trace.count, "duration_ms", duration.Milliseconds()}
+	if data.Err != nil {
+		t.log.Error("postgres: batch failed", append(attrs, "error", data.Err)...)
+		return
+	}
+	if t.opts.SlowQueryThreshold > 0 && duration >= t.opts.SlowQueryThreshold {
+		t.log.Warn("postgres: slow batch", attrs...)
+		return
+	}
+	t.log.Debug("postgres: batch", attrs...)
+}
+
+func (t *queryTracer) TraceConnectStart(ctx context.Context, data pgx.TraceConnectStartData) context.Context {
+	return context.WithValue(ctx, traceConnectCtxKey{}, connectTraceData{start: time.Now()})
+}
+
+func (t *queryTracer) TraceConnectEnd(ctx context.Context, data pgx.TraceConnectEndData) {
+	trace, _ := ctx.Value(traceConnectCtxKey{}).(connectTraceData)
+	duration := time.Since(trace.start)
+
+	attrs := []any{"host", data.ConnConfig.Host, "duration_ms", duration.Milliseconds()}
+	if data.Err != nil {
+		t.log.Error("postgres: connect failed", append(attrs, "error", data.Err)...)
+		return
+	}
+	t.log.Debug("postgres: connected", attrs...)
+}
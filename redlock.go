@@ -0,0 +1,89 @@
+package gokart
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// clockDriftFactor is the fraction of TTL subtracted from validity to account
+// for clock drift between the N independent Redis instances, per the
+// Redlock algorithm.
+const clockDriftFactor = 0.01
+
+// RedlockClient implements the Redlock algorithm across N independent
+// Redis instances (each wrapped in its own *Cache), for safety stronger
+// than a single-instance Lock can provide.
+type RedlockClient struct {
+	caches []*Cache
+	quorum int
+}
+
+// NewRedlockClient builds a Redlock client over N independent Redis
+// instances. At least 3 instances are recommended for a meaningful
+// quorum.
+//
+// Example:
+//
+//	rl := gokart.NewRedlockClient(cacheA, cacheB, cacheC)
+//	lock, err := rl.Lock(ctx, "invoice:123", gokart.LockOptions{TTL: 10 * time.Second})
+func NewRedlockClient(caches ...*Cache) *RedlockClient {
+	return &RedlockClient{
+		caches: caches,
+		quorum: len(caches)/2 + 1,
+	}
+}
+
+// RedlockHandle is a lock held across a quorum of Redlock instances.
+type RedlockHandle struct {
+	client *RedlockClient
+	key    string
+	token  string
+	held   []*Cache
+}
+
+// Lock attempts to acquire the lock on a quorum (N/2+1) of instances
+// within a clock-drift-adjusted validity window. On failure to reach
+// quorum it releases any partial acquisitions and returns
+// ErrLockNotAcquired.
+func (r *RedlockClient) Lock(ctx context.Context, key string, opts LockOptions) (*RedlockHandle, error) {
+	opts = opts.withDefaults()
+
+	start := time.Now()
+	held := make([]*Cache, 0, len(r.caches))
+
+	for _, cache := range r.caches {
+		ok, err := cache.SetNX(ctx, key, opts.Token, opts.TTL)
+		if err == nil && ok {
+			held = append(held, cache)
+		}
+	}
+
+	elapsed := time.Since(start)
+	drift := time.Duration(float64(opts.TTL) * clockDriftFactor)
+	validity := opts.TTL - elapsed - drift
+
+	if len(held) < r.quorum || validity <= 0 {
+		releaseAll(ctx, held, key, opts.Token)
+		return nil, ErrLockNotAcquired
+	}
+
+	return &RedlockHandle{client: r, key: key, token: opts.Token, held: held}, nil
+}
+
+// Unlock releases the lock on every instance that acquired it,
+// regardless of quorum, so a partially-held lock never lingers.
+func (h *RedlockHandle) Unlock(ctx context.Context) error {
+	return releaseAll(ctx, h.held, h.key, h.token)
+}
+
+func releaseAll(ctx context.Context, caches []*Cache, key, token string) error {
+	var firstErr error
+	for _, cache := range caches {
+		lock := &Lock{cache: cache, key: key, token: token}
+		if err := lock.Unlock(ctx); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("release on one redlock instance: %w", err)
+		}
+	}
+	return firstErr
+}
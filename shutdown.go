@@ -0,0 +1,146 @@
+package gokart
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ExitHook is one named teardown callback registered with Shutdown via
+// BeforeExit.
+type ExitHook struct {
+	// Name identifies the hook in logs.
+	Name string
+
+	// Priority controls run order: higher priorities run first. Hooks
+	// with equal Priority run in reverse-registration order (the last
+	// one registered closes first), matching the usual pattern of
+	// tearing down in the opposite order things were opened.
+	Priority int
+
+	Fn func(ctx context.Context) error
+}
+
+// ShutdownConfig configures a Shutdown coordinator.
+type ShutdownConfig struct {
+	// Signals are the signals Wait blocks on. Default: SIGINT, SIGTERM.
+	Signals []os.Signal
+
+	// Timeout bounds each individual hook call. Default: 10s.
+	Timeout time.Duration
+
+	// Logger receives per-hook start/duration/error logs. Default:
+	// slog.Default().
+	Logger *slog.Logger
+}
+
+func (c ShutdownConfig) withDefaults() ShutdownConfig {
+	if len(c.Signals) == 0 {
+		c.Signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	if c.Timeout == 0 {
+		c.Timeout = 10 * time.Second
+	}
+	if c.Logger == nil {
+		c.Logger = slog.Default()
+	}
+	return c
+}
+
+// Shutdown is a signal-driven teardown coordinator: register cleanup
+// funcs with BeforeExit, then call Wait to block until a signal (or the
+// passed-in context) fires, at which point the registered hooks run in
+// priority order with a per-hook timeout and logging.
+//
+// Shutdown is deliberately simpler than Lifecycle: it has no OnStart
+// phase or dependency graph, just an ordered list of "run this on the
+// way out" callbacks. Use Lifecycle when components need to be started
+// in dependency order too; use Shutdown when you only need orderly
+// teardown of things already running (an HTTP server, a pgxpool, a
+// spinner goroutine, a file logger).
+//
+// Example:
+//
+//	sd := gokart.NewShutdown(gokart.ShutdownConfig{})
+//	sd.BeforeExit("http", 0, func(ctx context.Context) error { return server.Shutdown(ctx) })
+//	sd.BeforeExit("postgres", -10, func(ctx context.Context) error { pool.Close(); return nil })
+//	if err := sd.Wait(context.Background()); err != nil {
+//	    log.Fatal(err)
+//	}
+type Shutdown struct {
+	cfg ShutdownConfig
+
+	mu    sync.Mutex
+	hooks []ExitHook
+}
+
+// NewShutdown returns a Shutdown coordinator ready for BeforeExit calls.
+func NewShutdown(cfg ShutdownConfig) *Shutdown {
+	return &Shutdown{cfg: cfg.withDefaults()}
+}
+
+// BeforeExit registers fn to run during shutdown under name, ordered by
+// priority (higher runs first; ties broken by reverse-registration
+// order).
+func (s *Shutdown) BeforeExit(name string, priority int, fn func(ctx context.Context) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hooks = append(s.hooks, ExitHook{Name: name, Priority: priority, Fn: fn})
+}
+
+// Wait blocks until ctx is done or a configured signal arrives, then
+// runs every registered hook in order, logging each one's name,
+// duration, and error. It returns a joined error of any hook failures.
+func (s *Shutdown) Wait(ctx context.Context) error {
+	sigCtx, stop := signal.NotifyContext(ctx, s.cfg.Signals...)
+	defer stop()
+	<-sigCtx.Done()
+
+	s.cfg.Logger.Info("shutdown: signal received, running exit hooks")
+
+	s.mu.Lock()
+	hooks := append([]ExitHook(nil), s.hooks...)
+	s.mu.Unlock()
+
+	order := make([]int, len(hooks))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		ai, bi := order[a], order[b]
+		if hooks[ai].Priority != hooks[bi].Priority {
+			return hooks[ai].Priority > hooks[bi].Priority
+		}
+		return ai > bi
+	})
+
+	var errs []error
+	for _, idx := range order {
+		h := hooks[idx]
+		if h.Fn == nil {
+			continue
+		}
+
+		start := time.Now()
+		stopCtx, cancel := context.WithTimeout(context.Background(), s.cfg.Timeout)
+		err := h.Fn(stopCtx)
+		cancel()
+		duration := time.Since(start)
+
+		if err != nil {
+			s.cfg.Logger.Error("shutdown: exit hook failed", "name", h.Name, "duration_ms", duration.Milliseconds(), "error", err)
+			errs = append(errs, fmt.Errorf("exit hook %q: %w", h.Name, err))
+			continue
+		}
+		s.cfg.Logger.Info("shutdown: exit hook done", "name", h.Name, "duration_ms", duration.Milliseconds())
+	}
+
+	return errors.Join(errs...)
+}
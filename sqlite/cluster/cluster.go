@@ -0,0 +1,268 @@
+// Package cluster wraps sqlite.Open with a Raft consensus layer so a small
+// group of Go processes can share one consistent SQLite database, the way
+// rqlite does as a standalone server — except here it's a library. Writes
+// go through the Raft leader and are applied to every follower's local
+// SQLite file via the same sqlite.Transaction helper the rest of the
+// module uses, keeping the "zero-CGO SQLite" property intact.
+package cluster
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dotcommander/gokart/sqlite"
+	"github.com/hashicorp/raft"
+	raftboltdb "github.com/hashicorp/raft-boltdb/v2"
+)
+
+// Consistency selects how strongly a Query should be ordered relative to
+// the Raft log.
+type Consistency int
+
+const (
+	// None reads from the local SQLite file directly. Fast, but may be
+	// stale on a follower that hasn't applied the latest committed entry.
+	None Consistency = iota
+	// Weak requires the node to be the current leader but does not wait
+	// for a log barrier, so a just-committed write on another node may
+	// not yet be visible.
+	Weak
+	// Strong issues a Raft barrier before reading, guaranteeing the read
+	// observes every write committed before the call began.
+	Strong
+)
+
+// ErrNotLeader is returned by write operations on a follower. LeaderHint,
+// when non-empty, is the last known leader address.
+type ErrNotLeader struct {
+	LeaderHint string
+}
+
+func (e *ErrNotLeader) Error() string {
+	if e.LeaderHint == "" {
+		return "cluster: not the leader (no known leader)"
+	}
+	return fmt.Sprintf("cluster: not the leader (leader is %s)", e.LeaderHint)
+}
+
+// Config configures a cluster node.
+type Config struct {
+	// NodeID uniquely identifies this node within the cluster.
+	NodeID string
+
+	// BindAddr is the address this node's Raft transport listens on,
+	// e.g. "127.0.0.1:7000".
+	BindAddr string
+
+	// DataDir stores the Raft log, stable store, and snapshots.
+	DataDir string
+
+	// Path is the local SQLite file applied writes are replayed into.
+	Path string
+
+	// Peers bootstraps a fresh cluster with this initial voter set. Leave
+	// empty when joining an existing cluster via Join.
+	Peers []raft.Server
+
+	// SnapshotThreshold is how many applied log entries accumulate
+	// before Raft takes a new snapshot. Default: 8192.
+	SnapshotThreshold uint64
+
+	// SQLite carries through to sqlite.OpenWithConfig for the local file.
+	SQLite sqlite.Config
+}
+
+// DB is a Raft-replicated SQLite database.
+type DB struct {
+	cfg   Config
+	local *sql.DB
+	raft  *raft.Raft
+	fsm   *fsm
+}
+
+// Open starts (or rejoins) a cluster node and returns once the local Raft
+// instance is created and, when Peers is set, bootstrapped. It does not
+// wait for a leader to be elected.
+//
+// Example:
+//
+//	db, err := cluster.Open(ctx, cluster.Config{
+//	    NodeID:   "node1",
+//	    BindAddr: "127.0.0.1:7000",
+//	    DataDir:  "/var/lib/myapp/raft",
+//	    Path:     "/var/lib/myapp/app.db",
+//	    Peers: []raft.Server{
+//	        {ID: "node1", Address: "127.0.0.1:7000"},
+//	    },
+//	})
+func Open(ctx context.Context, cfg Config) (*DB, error) {
+	if cfg.SnapshotThreshold == 0 {
+		cfg.SnapshotThreshold = 8192
+	}
+	sqliteCfg := cfg.SQLite
+	if sqliteCfg.Path == "" {
+		sqliteCfg.Path = cfg.Path
+	}
+
+	local, err := sqlite.OpenWithConfig(ctx, sqliteCfg)
+	if err != nil {
+		return nil, fmt.Errorf("open local sqlite: %w", err)
+	}
+
+	if err := os.MkdirAll(cfg.DataDir, 0755); err != nil {
+		local.Close()
+		return nil, fmt.Errorf("create raft data dir: %w", err)
+	}
+
+	machine := &fsm{db: local}
+
+	raftCfg := raft.DefaultConfig()
+	raftCfg.LocalID = raft.ServerID(cfg.NodeID)
+	raftCfg.SnapshotThreshold = cfg.SnapshotThreshold
+
+	addr, err := net.ResolveTCPAddr("tcp", cfg.BindAddr)
+	if err != nil {
+		local.Close()
+		return nil, fmt.Errorf("resolve bind addr: %w", err)
+	}
+	transport, err := raft.NewTCPTransport(cfg.BindAddr, addr, 3, 10*time.Second, os.Stderr)
+	if err != nil {
+		local.Close()
+		return nil, fmt.Errorf("create raft transport: %w", err)
+	}
+
+	snapshots, err := raft.NewFileSnapshotStore(cfg.DataDir, 2, os.Stderr)
+	if err != nil {
+		local.Close()
+		return nil, fmt.Errorf("create snapshot store: %w", err)
+	}
+
+	logStore, err := raftboltdb.NewBoltStore(filepath.Join(cfg.DataDir, "raft-log.bolt"))
+	if err != nil {
+		local.Close()
+		return nil, fmt.Errorf("create raft log store: %w", err)
+	}
+	stableStore, err := raftboltdb.NewBoltStore(filepath.Join(cfg.DataDir, "raft-stable.bolt"))
+	if err != nil {
+		local.Close()
+		return nil, fmt.Errorf("create raft stable store: %w", err)
+	}
+
+	r, err := raft.NewRaft(raftCfg, machine, logStore, stableStore, snapshots, transport)
+	if err != nil {
+		local.Close()
+		return nil, fmt.Errorf("create raft node: %w", err)
+	}
+
+	if len(cfg.Peers) > 0 {
+		bootstrap := raft.Configuration{Servers: cfg.Peers}
+		if fut := r.BootstrapCluster(bootstrap); fut.Error() != nil && fut.Error() != raft.ErrCantBootstrap {
+			local.Close()
+			return nil, fmt.Errorf("bootstrap cluster: %w", fut.Error())
+		}
+	}
+
+	return &DB{cfg: cfg, local: local, raft: r, fsm: machine}, nil
+}
+
+// ExecContext runs a write statement. On a follower it returns
+// *ErrNotLeader; callers should retry against the hinted leader.
+func (db *DB) ExecContext(ctx context.Context, query string, args ...any) error {
+	if db.raft.State() != raft.Leader {
+		return &ErrNotLeader{LeaderHint: string(db.currentLeader())}
+	}
+
+	cmd, err := encodeCommand(query, args)
+	if err != nil {
+		return fmt.Errorf("encode command: %w", err)
+	}
+
+	future := db.raft.Apply(cmd, applyTimeout(ctx))
+	if err := future.Error(); err != nil {
+		return fmt.Errorf("raft apply: %w", err)
+	}
+	if resp, ok := future.Response().(error); ok && resp != nil {
+		return resp
+	}
+	return nil
+}
+
+// QueryContext runs a read query at the requested consistency level
+// against the local SQLite file.
+func (db *DB) QueryContext(ctx context.Context, level Consistency, query string, args ...any) (*sql.Rows, error) {
+	switch level {
+	case Weak:
+		if db.raft.State() != raft.Leader {
+			return nil, &ErrNotLeader{LeaderHint: string(db.currentLeader())}
+		}
+	case Strong:
+		if err := db.raft.Barrier(applyTimeout(ctx)).Error(); err != nil {
+			return nil, fmt.Errorf("raft barrier: %w", err)
+		}
+	case None:
+		// local, possibly-stale read is fine
+	}
+	return db.local.QueryContext(ctx, query, args...)
+}
+
+// Transaction replicates one write statement through Raft, exactly as
+// ExecContext does: query is applied on every node's local database
+// inside sqlite.Transaction once the command commits to the log.
+func (db *DB) Transaction(ctx context.Context, query string, args ...any) error {
+	return db.ExecContext(ctx, query, args...)
+}
+
+// Leader returns the address of the current Raft leader, if known.
+func (db *DB) Leader() string {
+	return string(db.currentLeader())
+}
+
+// Stats returns the underlying Raft node's diagnostic counters (state,
+// term, log index, etc.) for health checks and dashboards.
+func (db *DB) Stats() map[string]string {
+	return db.raft.Stats()
+}
+
+// Join adds a voting peer to the cluster. Must be called on the leader.
+func (db *DB) Join(id, addr string) error {
+	if db.raft.State() != raft.Leader {
+		return &ErrNotLeader{LeaderHint: string(db.currentLeader())}
+	}
+	future := db.raft.AddVoter(raft.ServerID(id), raft.ServerAddress(addr), 0, 0)
+	return future.Error()
+}
+
+// Leave removes a peer from the cluster. Must be called on the leader.
+func (db *DB) Leave(id string) error {
+	if db.raft.State() != raft.Leader {
+		return &ErrNotLeader{LeaderHint: string(db.currentLeader())}
+	}
+	future := db.raft.RemoveServer(raft.ServerID(id), 0, 0)
+	return future.Error()
+}
+
+// Close shuts down the Raft node and the local SQLite connection.
+func (db *DB) Close() error {
+	if err := db.raft.Shutdown().Error(); err != nil {
+		db.local.Close()
+		return fmt.Errorf("shutdown raft: %w", err)
+	}
+	return db.local.Close()
+}
+
+func (db *DB) currentLeader() raft.ServerAddress {
+	addr, _ := db.raft.LeaderWithID()
+	return addr
+}
+
+func applyTimeout(ctx context.Context) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		return time.Until(deadline)
+	}
+	return 10 * time.Second
+}
@@ -0,0 +1,234 @@
+package cluster
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dotcommander/gokart/sqlite"
+	"github.com/hashicorp/raft"
+)
+
+// command is the Raft log entry applied to every node's local SQLite
+// database.
+type command struct {
+	Query string `json:"query"`
+	Args  []any  `json:"args"`
+}
+
+func encodeCommand(query string, args []any) ([]byte, error) {
+	return json.Marshal(command{Query: query, Args: args})
+}
+
+// fsm implements raft.FSM by replaying committed commands through
+// sqlite.Transaction, the same helper used outside the cluster package.
+type fsm struct {
+	db *sql.DB
+}
+
+// Apply executes one committed command against the local database.
+func (f *fsm) Apply(entry *raft.Log) any {
+	var cmd command
+	if err := json.Unmarshal(entry.Data, &cmd); err != nil {
+		return fmt.Errorf("decode command: %w", err)
+	}
+
+	err := sqlite.Transaction(context.Background(), f.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(context.Background(), cmd.Query, cmd.Args...)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Snapshot takes a consistent point-in-time copy of the local database
+// using VACUUM INTO, which SQLite guarantees is transactionally
+// consistent even while WAL writers are active.
+func (f *fsm) Snapshot() (raft.FSMSnapshot, error) {
+	tmp, err := os.CreateTemp("", "gokart-cluster-snapshot-*.db")
+	if err != nil {
+		return nil, fmt.Errorf("create snapshot temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	tmp.Close()
+	os.Remove(tmpPath) // VACUUM INTO requires the destination not to exist
+
+	if _, err := f.db.ExecContext(context.Background(), "VACUUM INTO ?", tmpPath); err != nil {
+		os.Remove(tmpPath)
+		return nil, fmt.Errorf("vacuum into snapshot: %w", err)
+	}
+
+	return &fsmSnapshot{path: tmpPath}, nil
+}
+
+// Restore replaces the local database's contents with those of a
+// previously taken snapshot.
+func (f *fsm) Restore(r io.ReadCloser) error {
+	defer r.Close()
+
+	tmp, err := os.CreateTemp("", "gokart-cluster-restore-*.db")
+	if err != nil {
+		return fmt.Errorf("create restore temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write restore snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close restore snapshot: %w", err)
+	}
+
+	ctx := context.Background()
+
+	// ATTACH is scoped to the connection that issues it, so the whole
+	// restore — attach, schema swap, detach — has to run on one
+	// *sql.Conn pulled from the pool rather than the pooled *sql.DB,
+	// which could hand each statement to a different connection.
+	conn, err := f.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire restore connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return fmt.Errorf("checkpoint before restore: %w", err)
+	}
+
+	if _, err := conn.ExecContext(ctx, "ATTACH DATABASE ? AS restore_src", tmp.Name()); err != nil {
+		return fmt.Errorf("attach restore snapshot: %w", err)
+	}
+	defer conn.ExecContext(ctx, "DETACH DATABASE restore_src")
+
+	if err := replaceMainSchema(ctx, conn); err != nil {
+		return fmt.Errorf("restore snapshot: %w", err)
+	}
+	return nil
+}
+
+// replaceMainSchema drops every user table and view in main and recreates
+// everything — tables (with their rows), indexes, triggers, and views —
+// from the attached restore_src database, so installing a Raft snapshot
+// actually overwrites local state instead of leaving it untouched.
+// Indexes and triggers don't need dropping up front: SQLite drops them
+// automatically along with the table they belong to.
+func replaceMainSchema(ctx context.Context, conn *sql.Conn) error {
+	existingTables, err := schemaObjects(ctx, conn, "main", "table")
+	if err != nil {
+		return fmt.Errorf("list existing tables: %w", err)
+	}
+	for _, t := range existingTables {
+		if _, err := conn.ExecContext(ctx, "DROP TABLE main."+quoteIdent(t.name)); err != nil {
+			return fmt.Errorf("drop table %s: %w", t.name, err)
+		}
+	}
+
+	existingViews, err := schemaObjects(ctx, conn, "main", "view")
+	if err != nil {
+		return fmt.Errorf("list existing views: %w", err)
+	}
+	for _, v := range existingViews {
+		if _, err := conn.ExecContext(ctx, "DROP VIEW main."+quoteIdent(v.name)); err != nil {
+			return fmt.Errorf("drop view %s: %w", v.name, err)
+		}
+	}
+
+	tables, err := schemaObjects(ctx, conn, "restore_src", "table")
+	if err != nil {
+		return fmt.Errorf("list restore_src tables: %w", err)
+	}
+	for _, t := range tables {
+		if _, err := conn.ExecContext(ctx, t.sql); err != nil {
+			return fmt.Errorf("recreate table %s: %w", t.name, err)
+		}
+		ident := quoteIdent(t.name)
+		if _, err := conn.ExecContext(ctx, "INSERT INTO main."+ident+" SELECT * FROM restore_src."+ident); err != nil {
+			return fmt.Errorf("copy table %s: %w", t.name, err)
+		}
+	}
+
+	// Indexes, triggers, and views are recreated after the table data is
+	// loaded (a view or trigger may reference rows/columns that don't
+	// fully exist until then, and an index is cheaper to build over
+	// already-populated data than row by row).
+	for _, objType := range []string{"index", "trigger", "view"} {
+		objs, err := schemaObjects(ctx, conn, "restore_src", objType)
+		if err != nil {
+			return fmt.Errorf("list restore_src %ss: %w", objType, err)
+		}
+		for _, o := range objs {
+			if _, err := conn.ExecContext(ctx, o.sql); err != nil {
+				return fmt.Errorf("recreate %s %s: %w", objType, o.name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+type schemaObject struct{ name, sql string }
+
+// schemaObjects lists the name and defining SQL of every object of
+// objType ("table", "index", "trigger", or "view") in schema ("main" or
+// an attached database name), skipping SQLite's own sqlite_autoindex_*
+// indexes — those are recreated implicitly by their owning table's DDL,
+// not by replaying a CREATE INDEX statement.
+func schemaObjects(ctx context.Context, conn *sql.Conn, schema, objType string) ([]schemaObject, error) {
+	rows, err := conn.QueryContext(ctx, fmt.Sprintf(`
+		SELECT name, sql FROM %s.sqlite_master
+		WHERE type = ? AND name NOT LIKE 'sqlite_%%'
+		ORDER BY rowid
+	`, schema), objType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var objs []schemaObject
+	for rows.Next() {
+		var o schemaObject
+		if err := rows.Scan(&o.name, &o.sql); err != nil {
+			return nil, err
+		}
+		objs = append(objs, o)
+	}
+	return objs, rows.Err()
+}
+
+// quoteIdent double-quotes a SQLite identifier, doubling any embedded
+// double quotes per the SQL standard escaping rule.
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// fsmSnapshot streams a VACUUM INTO'd file to Raft's snapshot sink and
+// cleans up the temp file afterward.
+type fsmSnapshot struct {
+	path string
+}
+
+func (s *fsmSnapshot) Persist(sink raft.SnapshotSink) error {
+	f, err := os.Open(s.path)
+	if err != nil {
+		sink.Cancel()
+		return fmt.Errorf("open snapshot file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(sink, f); err != nil {
+		sink.Cancel()
+		return fmt.Errorf("persist snapshot: %w", err)
+	}
+	return sink.Close()
+}
+
+func (s *fsmSnapshot) Release() {
+	os.Remove(s.path)
+}
@@ -0,0 +1,179 @@
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotcommander/gokart/sqlite"
+)
+
+func TestFSMRestore_ReplacesContents(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	// Build a source database file with some data, the way Snapshot's
+	// VACUUM INTO would produce one.
+	srcPath := filepath.Join(t.TempDir(), "source.db")
+	srcCfg := sqlite.DefaultConfig(srcPath)
+	srcCfg.MaxOpenConns = 1
+	srcDB, err := sqlite.OpenWithConfig(ctx, srcCfg)
+	if err != nil {
+		t.Fatalf("open source db: %v", err)
+	}
+	if _, err := srcDB.ExecContext(ctx, `CREATE TABLE widgets (id INTEGER PRIMARY KEY, name TEXT)`); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	if _, err := srcDB.ExecContext(ctx, `INSERT INTO widgets (id, name) VALUES (1, 'gear')`); err != nil {
+		t.Fatalf("insert row: %v", err)
+	}
+	srcDB.Close()
+
+	snapshotBytes, err := os.ReadFile(srcPath)
+	if err != nil {
+		t.Fatalf("read source db file: %v", err)
+	}
+
+	// The destination starts with different, stale data — Restore must
+	// actually wipe and replace it, not just ATTACH/DETACH and leave it
+	// untouched.
+	dstCfg := sqlite.DefaultConfig(":memory:")
+	dstCfg.MaxOpenConns = 1
+	dstDB, err := sqlite.OpenWithConfig(ctx, dstCfg)
+	if err != nil {
+		t.Fatalf("open dest db: %v", err)
+	}
+	defer dstDB.Close()
+
+	if _, err := dstDB.ExecContext(ctx, `CREATE TABLE widgets (id INTEGER PRIMARY KEY, name TEXT)`); err != nil {
+		t.Fatalf("create stale table: %v", err)
+	}
+	if _, err := dstDB.ExecContext(ctx, `INSERT INTO widgets (id, name) VALUES (99, 'stale')`); err != nil {
+		t.Fatalf("insert stale row: %v", err)
+	}
+
+	f := &fsm{db: dstDB}
+	if err := f.Restore(io.NopCloser(bytes.NewReader(snapshotBytes))); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+
+	rows, err := dstDB.QueryContext(ctx, `SELECT id, name FROM widgets ORDER BY id`)
+	if err != nil {
+		t.Fatalf("query after restore: %v", err)
+	}
+	defer rows.Close()
+
+	var got []string
+	for rows.Next() {
+		var id int
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			t.Fatalf("scan: %v", err)
+		}
+		got = append(got, fmt.Sprintf("%d:%s", id, name))
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("rows: %v", err)
+	}
+
+	if len(got) != 1 || got[0] != "1:gear" {
+		t.Fatalf("expected restored contents [1:gear], got %v", got)
+	}
+}
+
+func TestFSMRestore_PreservesIndexesTriggersAndViews(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	srcPath := filepath.Join(t.TempDir(), "source.db")
+	srcCfg := sqlite.DefaultConfig(srcPath)
+	srcCfg.MaxOpenConns = 1
+	srcDB, err := sqlite.OpenWithConfig(ctx, srcCfg)
+	if err != nil {
+		t.Fatalf("open source db: %v", err)
+	}
+	if _, err := srcDB.ExecContext(ctx, `CREATE TABLE widgets (id INTEGER PRIMARY KEY, name TEXT)`); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	if _, err := srcDB.ExecContext(ctx, `CREATE INDEX widgets_name_idx ON widgets (name)`); err != nil {
+		t.Fatalf("create index: %v", err)
+	}
+	if _, err := srcDB.ExecContext(ctx, `CREATE TABLE widget_log (id INTEGER PRIMARY KEY, widget_id INTEGER, note TEXT)`); err != nil {
+		t.Fatalf("create log table: %v", err)
+	}
+	if _, err := srcDB.ExecContext(ctx, `
+		CREATE TRIGGER widgets_ai AFTER INSERT ON widgets BEGIN
+			INSERT INTO widget_log (widget_id, note) VALUES (NEW.id, 'inserted');
+		END
+	`); err != nil {
+		t.Fatalf("create trigger: %v", err)
+	}
+	if _, err := srcDB.ExecContext(ctx, `CREATE VIEW widget_names AS SELECT name FROM widgets`); err != nil {
+		t.Fatalf("create view: %v", err)
+	}
+	if _, err := srcDB.ExecContext(ctx, `INSERT INTO widgets (id, name) VALUES (1, 'gear')`); err != nil {
+		t.Fatalf("insert row: %v", err)
+	}
+	srcDB.Close()
+
+	snapshotBytes, err := os.ReadFile(srcPath)
+	if err != nil {
+		t.Fatalf("read source db file: %v", err)
+	}
+
+	dstCfg := sqlite.DefaultConfig(":memory:")
+	dstCfg.MaxOpenConns = 1
+	dstDB, err := sqlite.OpenWithConfig(ctx, dstCfg)
+	if err != nil {
+		t.Fatalf("open dest db: %v", err)
+	}
+	defer dstDB.Close()
+
+	// The destination starts with a stale view referencing a table that's
+	// about to be dropped — Restore must clear it along with stale tables.
+	if _, err := dstDB.ExecContext(ctx, `CREATE TABLE widgets (id INTEGER PRIMARY KEY, name TEXT)`); err != nil {
+		t.Fatalf("create stale table: %v", err)
+	}
+	if _, err := dstDB.ExecContext(ctx, `CREATE VIEW stale_view AS SELECT name FROM widgets`); err != nil {
+		t.Fatalf("create stale view: %v", err)
+	}
+
+	f := &fsm{db: dstDB}
+	if err := f.Restore(io.NopCloser(bytes.NewReader(snapshotBytes))); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+
+	var indexCount int
+	if err := dstDB.QueryRowContext(ctx, `SELECT count(*) FROM sqlite_master WHERE type = 'index' AND name = 'widgets_name_idx'`).Scan(&indexCount); err != nil {
+		t.Fatalf("query index: %v", err)
+	}
+	if indexCount != 1 {
+		t.Fatalf("expected widgets_name_idx to survive restore, got count %d", indexCount)
+	}
+
+	var viewCount int
+	if err := dstDB.QueryRowContext(ctx, `SELECT count(*) FROM sqlite_master WHERE type = 'view' AND name IN ('widget_names', 'stale_view')`).Scan(&viewCount); err != nil {
+		t.Fatalf("query views: %v", err)
+	}
+	if viewCount != 1 {
+		t.Fatalf("expected only widget_names to remain after restore, got count %d", viewCount)
+	}
+
+	if _, err := dstDB.ExecContext(ctx, `INSERT INTO widgets (id, name) VALUES (2, 'bolt')`); err != nil {
+		t.Fatalf("insert after restore: %v", err)
+	}
+
+	var logCount int
+	if err := dstDB.QueryRowContext(ctx, `SELECT count(*) FROM widget_log WHERE widget_id = 2`).Scan(&logCount); err != nil {
+		t.Fatalf("query widget_log: %v", err)
+	}
+	if logCount != 1 {
+		t.Fatalf("expected widgets_ai trigger to fire after restore, got %d log rows", logCount)
+	}
+}
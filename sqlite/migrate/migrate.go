@@ -0,0 +1,212 @@
+// Package migrate describes ordered sets of SQLite schema migrations.
+//
+// A Set can be built from Go functions (RegisterFunc) or from a directory of
+// paired "NNN_name.up.sql" / "NNN_name.down.sql" files embedded with
+// embed.FS (RegisterFS). The sqlite package applies a Set against a
+// *sql.DB; this package only knows how to describe and validate one.
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Migration is a single schema change identified by a monotonically
+// increasing Version. Checksum is computed from the migration's source
+// (SQL text or a caller-supplied string) so that drift in an already
+// applied migration can be detected.
+type Migration struct {
+	Version  int
+	Name     string
+	Checksum string
+
+	Up   func(ctx context.Context, tx Tx) error
+	Down func(ctx context.Context, tx Tx) error
+}
+
+// Tx is the subset of *sql.Tx a migration needs. It exists so this package
+// does not have to import database/sql just to describe migrations.
+type Tx interface {
+	ExecContext(ctx context.Context, query string, args ...any) (Result, error)
+}
+
+// Result mirrors sql.Result's shape without importing database/sql.
+type Result interface {
+	LastInsertId() (int64, error)
+	RowsAffected() (int64, error)
+}
+
+// Set is an ordered collection of migrations.
+type Set struct {
+	migrations map[int]Migration
+}
+
+// NewSet creates an empty migration Set.
+func NewSet() *Set {
+	return &Set{migrations: make(map[int]Migration)}
+}
+
+// RegisterFunc adds a migration defined by Go functions.
+//
+// Example:
+//
+//	set := migrate.NewSet()
+//	set.RegisterFunc(1, "create_users", func(ctx context.Context, tx migrate.Tx) error {
+//	    _, err := tx.ExecContext(ctx, `CREATE TABLE users (id INTEGER PRIMARY KEY)`)
+//	    return err
+//	}, nil)
+func (s *Set) RegisterFunc(version int, name string, up, down func(ctx context.Context, tx Tx) error) *Set {
+	s.migrations[version] = Migration{
+		Version:  version,
+		Name:     name,
+		Checksum: checksum(name),
+		Up:       up,
+		Down:     down,
+	}
+	return s
+}
+
+// RegisterFS parses an embed.FS (or any fs.FS) for pairs of
+// "NNN_name.up.sql" / "NNN_name.down.sql" files and registers one
+// Migration per version. A missing down file is allowed (the migration is
+// then irreversible); a missing up file is an error.
+//
+// Example:
+//
+//	//go:embed migrations/*.sql
+//	var migrationFS embed.FS
+//
+//	set := migrate.NewSet()
+//	if err := set.RegisterFS(migrationFS, "migrations"); err != nil {
+//	    log.Fatal(err)
+//	}
+func (s *Set) RegisterFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return fmt.Errorf("read migrations dir %q: %w", dir, err)
+	}
+
+	type pair struct {
+		version  int
+		name     string
+		upPath   string
+		downPath string
+	}
+	pairs := make(map[int]*pair)
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		version, name, direction, ok := parseFilename(entry.Name())
+		if !ok {
+			continue
+		}
+		p, exists := pairs[version]
+		if !exists {
+			p = &pair{version: version, name: name}
+			pairs[version] = p
+		}
+		full := path.Join(dir, entry.Name())
+		switch direction {
+		case "up":
+			p.upPath = full
+		case "down":
+			p.downPath = full
+		}
+	}
+
+	for version, p := range pairs {
+		if p.upPath == "" {
+			return fmt.Errorf("migration %03d_%s: missing .up.sql file", version, p.name)
+		}
+		upSQL, err := fs.ReadFile(fsys, p.upPath)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", p.upPath, err)
+		}
+
+		var downSQL []byte
+		if p.downPath != "" {
+			downSQL, err = fs.ReadFile(fsys, p.downPath)
+			if err != nil {
+				return fmt.Errorf("read %s: %w", p.downPath, err)
+			}
+		}
+
+		s.migrations[version] = Migration{
+			Version:  version,
+			Name:     p.name,
+			Checksum: checksum(string(upSQL)),
+			Up:       sqlExec(string(upSQL)),
+			Down:     sqlExecIfPresent(downSQL),
+		}
+	}
+
+	return nil
+}
+
+// Sorted returns the migrations ordered by Version ascending.
+func (s *Set) Sorted() []Migration {
+	out := make([]Migration, 0, len(s.migrations))
+	for _, m := range s.migrations {
+		out = append(out, m)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+	return out
+}
+
+// Get returns the migration registered at version, if any.
+func (s *Set) Get(version int) (Migration, bool) {
+	m, ok := s.migrations[version]
+	return m, ok
+}
+
+func sqlExec(sql string) func(ctx context.Context, tx Tx) error {
+	return func(ctx context.Context, tx Tx) error {
+		_, err := tx.ExecContext(ctx, sql)
+		return err
+	}
+}
+
+func sqlExecIfPresent(sql []byte) func(ctx context.Context, tx Tx) error {
+	if len(strings.TrimSpace(string(sql))) == 0 {
+		return nil
+	}
+	return sqlExec(string(sql))
+}
+
+func parseFilename(name string) (version int, migName string, direction string, ok bool) {
+	base := strings.TrimSuffix(name, ".sql")
+	switch {
+	case strings.HasSuffix(base, ".up"):
+		direction = "up"
+		base = strings.TrimSuffix(base, ".up")
+	case strings.HasSuffix(base, ".down"):
+		direction = "down"
+		base = strings.TrimSuffix(base, ".down")
+	default:
+		return 0, "", "", false
+	}
+
+	parts := strings.SplitN(base, "_", 2)
+	if len(parts) != 2 {
+		return 0, "", "", false
+	}
+	v, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", "", false
+	}
+	return v, parts[1], direction, true
+}
+
+func checksum(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,330 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/dotcommander/gokart/sqlite/migrate"
+)
+
+// MigrationStatus describes one migration's applied/pending state, as
+// returned by Status for CLI tooling.
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+const createMigrationsTable = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+	version    INTEGER PRIMARY KEY,
+	name       TEXT NOT NULL,
+	applied_at TIMESTAMP NOT NULL,
+	checksum   TEXT NOT NULL
+)`
+
+// Migrate applies every pending migration in source, in version order.
+//
+// Example:
+//
+//	set := migrate.NewSet()
+//	set.RegisterFS(migrationFS, "migrations")
+//	err := sqlite.Migrate(ctx, db, set)
+func Migrate(ctx context.Context, db *sql.DB, source *migrate.Set) error {
+	return MigrateTo(ctx, db, source, latestVersion(source))
+}
+
+// MigrateTo applies (or, if version is lower than the current schema
+// version, does nothing for — use Rollback to go backwards) pending
+// migrations up to and including version.
+func MigrateTo(ctx context.Context, db *sql.DB, source *migrate.Set, version int) error {
+	return withMigrationLock(ctx, db, func(conn *sql.Conn) error {
+		if err := ensureMigrationsTable(ctx, conn); err != nil {
+			return err
+		}
+		if err := verifyChecksums(ctx, conn, source); err != nil {
+			return err
+		}
+
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range source.Sorted() {
+			if m.Version > version {
+				break
+			}
+			if applied[m.Version] {
+				continue
+			}
+			if err := applyMigration(ctx, conn, m); err != nil {
+				return fmt.Errorf("apply migration %03d_%s: %w", m.Version, m.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// Rollback undoes the last steps applied migrations, in reverse order,
+// using each migration's Down function.
+func Rollback(ctx context.Context, db *sql.DB, source *migrate.Set, steps int) error {
+	return withMigrationLock(ctx, db, func(conn *sql.Conn) error {
+		if err := ensureMigrationsTable(ctx, conn); err != nil {
+			return err
+		}
+
+		versions, err := appliedVersionsDesc(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := 0; i < steps && i < len(versions); i++ {
+			version := versions[i]
+			m, ok := source.Get(version)
+			if !ok {
+				return fmt.Errorf("rollback: no migration registered for applied version %d", version)
+			}
+			if m.Down == nil {
+				return fmt.Errorf("rollback: migration %03d_%s has no down step", m.Version, m.Name)
+			}
+			if err := revertMigration(ctx, conn, m); err != nil {
+				return fmt.Errorf("rollback migration %03d_%s: %w", m.Version, m.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// Plan returns the migrations that Migrate would apply, without running
+// them — the basis for a dry-run CLI mode.
+func Plan(ctx context.Context, db *sql.DB, source *migrate.Set) ([]migrate.Migration, error) {
+	var plan []migrate.Migration
+	err := withMigrationLock(ctx, db, func(conn *sql.Conn) error {
+		if err := ensureMigrationsTable(ctx, conn); err != nil {
+			return err
+		}
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, m := range source.Sorted() {
+			if !applied[m.Version] {
+				plan = append(plan, m)
+			}
+		}
+		return nil
+	})
+	return plan, err
+}
+
+// Status reports the applied/pending state of every migration in source,
+// ordered by version, for CLI tooling such as `gokart migrate status`.
+func Status(ctx context.Context, db *sql.DB, source *migrate.Set) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := withMigrationLock(ctx, db, func(conn *sql.Conn) error {
+		if err := ensureMigrationsTable(ctx, conn); err != nil {
+			return err
+		}
+
+		rows, err := conn.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+		if err != nil {
+			return fmt.Errorf("query schema_migrations: %w", err)
+		}
+		defer rows.Close()
+
+		appliedAt := make(map[int]time.Time)
+		for rows.Next() {
+			var version int
+			var at time.Time
+			if err := rows.Scan(&version, &at); err != nil {
+				return fmt.Errorf("scan schema_migrations: %w", err)
+			}
+			appliedAt[version] = at
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for _, m := range source.Sorted() {
+			at, ok := appliedAt[m.Version]
+			statuses = append(statuses, MigrationStatus{
+				Version:   m.Version,
+				Name:      m.Name,
+				Applied:   ok,
+				AppliedAt: at,
+			})
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// withMigrationLock runs fn on a dedicated connection with an exclusive
+// SQLite transaction lock held across setup so concurrent processes can't
+// double-apply migrations. fn runs inside that transaction and gives
+// each migration its own SAVEPOINT (see applyMigration).
+func withMigrationLock(ctx context.Context, db *sql.DB, fn func(conn *sql.Conn) error) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire migration connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "BEGIN EXCLUSIVE"); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+
+	if err := fn(conn); err != nil {
+		_, _ = conn.ExecContext(ctx, "ROLLBACK")
+		return err
+	}
+
+	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+		return fmt.Errorf("release migration lock: %w", err)
+	}
+	return nil
+}
+
+func ensureMigrationsTable(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, createMigrationsTable)
+	if err != nil {
+		return fmt.Errorf("create schema_migrations table: %w", err)
+	}
+	return nil
+}
+
+func verifyChecksums(ctx context.Context, conn *sql.Conn, source *migrate.Set) error {
+	rows, err := conn.QueryContext(ctx, `SELECT version, checksum FROM schema_migrations`)
+	if err != nil {
+		return fmt.Errorf("query schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version int
+		var checksum string
+		if err := rows.Scan(&version, &checksum); err != nil {
+			return fmt.Errorf("scan schema_migrations: %w", err)
+		}
+		m, ok := source.Get(version)
+		if !ok {
+			continue
+		}
+		if m.Checksum != checksum {
+			return fmt.Errorf("migration %03d_%s: checksum drift (applied %s, registered %s) — do not edit applied migrations", version, m.Name, checksum, m.Checksum)
+		}
+	}
+	return rows.Err()
+}
+
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[int]bool, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("query schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]bool)
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("scan schema_migrations: %w", err)
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+func appliedVersionsDesc(ctx context.Context, conn *sql.Conn) ([]int, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version FROM schema_migrations ORDER BY version DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("query schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []int
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("scan schema_migrations: %w", err)
+		}
+		versions = append(versions, version)
+	}
+	return versions, rows.Err()
+}
+
+// applyMigration and revertMigration run inside the single BEGIN
+// EXCLUSIVE transaction withMigrationLock already holds on conn — SQLite
+// rejects a nested BeginTx on the same connection ("cannot start a
+// transaction within a transaction"), so each migration gets its own
+// SAVEPOINT instead, letting it roll back independently of the
+// surrounding lock transaction on failure.
+func applyMigration(ctx context.Context, conn *sql.Conn, m migrate.Migration) error {
+	savepoint := migrationSavepoint(m.Version)
+	if _, err := conn.ExecContext(ctx, "SAVEPOINT "+savepoint); err != nil {
+		return fmt.Errorf("begin savepoint: %w", err)
+	}
+
+	if err := m.Up(ctx, connAdapter{conn}); err != nil {
+		_, _ = conn.ExecContext(ctx, "ROLLBACK TO "+savepoint)
+		return err
+	}
+
+	if _, err := conn.ExecContext(ctx,
+		`INSERT INTO schema_migrations (version, name, applied_at, checksum) VALUES (?, ?, ?, ?)`,
+		m.Version, m.Name, time.Now().UTC(), m.Checksum,
+	); err != nil {
+		_, _ = conn.ExecContext(ctx, "ROLLBACK TO "+savepoint)
+		return fmt.Errorf("record migration: %w", err)
+	}
+
+	if _, err := conn.ExecContext(ctx, "RELEASE "+savepoint); err != nil {
+		return fmt.Errorf("release savepoint: %w", err)
+	}
+	return nil
+}
+
+func revertMigration(ctx context.Context, conn *sql.Conn, m migrate.Migration) error {
+	savepoint := migrationSavepoint(m.Version)
+	if _, err := conn.ExecContext(ctx, "SAVEPOINT "+savepoint); err != nil {
+		return fmt.Errorf("begin savepoint: %w", err)
+	}
+
+	if err := m.Down(ctx, connAdapter{conn}); err != nil {
+		_, _ = conn.ExecContext(ctx, "ROLLBACK TO "+savepoint)
+		return err
+	}
+
+	if _, err := conn.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = ?`, m.Version); err != nil {
+		_, _ = conn.ExecContext(ctx, "ROLLBACK TO "+savepoint)
+		return fmt.Errorf("remove migration record: %w", err)
+	}
+
+	if _, err := conn.ExecContext(ctx, "RELEASE "+savepoint); err != nil {
+		return fmt.Errorf("release savepoint: %w", err)
+	}
+	return nil
+}
+
+func migrationSavepoint(version int) string {
+	return fmt.Sprintf("migration_%d", version)
+}
+
+func latestVersion(source *migrate.Set) int {
+	sorted := source.Sorted()
+	if len(sorted) == 0 {
+		return 0
+	}
+	return sorted[len(sorted)-1].Version
+}
+
+// connAdapter adapts *sql.Conn to the migrate.Tx interface.
+type connAdapter struct{ conn *sql.Conn }
+
+func (a connAdapter) ExecContext(ctx context.Context, query string, args ...any) (migrate.Result, error) {
+	return a.conn.ExecContext(ctx, query, args...)
+}
@@ -0,0 +1,73 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dotcommander/gokart/sqlite"
+	"github.com/dotcommander/gokart/sqlite/migrate"
+)
+
+func TestMigrateAndRollback_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	// MaxOpenConns: 1 so every operation reuses the same in-memory
+	// database instead of each pooled connection getting its own.
+	cfg := sqlite.DefaultConfig(":memory:")
+	cfg.MaxOpenConns = 1
+	db, err := sqlite.OpenWithConfig(ctx, cfg)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer db.Close()
+
+	set := migrate.NewSet()
+	set.RegisterFunc(1, "create_widgets",
+		func(ctx context.Context, tx migrate.Tx) error {
+			_, err := tx.ExecContext(ctx, `CREATE TABLE widgets (id INTEGER PRIMARY KEY)`)
+			return err
+		},
+		func(ctx context.Context, tx migrate.Tx) error {
+			_, err := tx.ExecContext(ctx, `DROP TABLE widgets`)
+			return err
+		},
+	)
+
+	// This is the regression case: Migrate used to fail with "cannot
+	// start a transaction within a transaction" on any pending migration,
+	// because withMigrationLock's BEGIN EXCLUSIVE and applyMigration's
+	// BeginTx both ran on the same *sql.Conn.
+	if err := sqlite.Migrate(ctx, db, set); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	if _, err := db.ExecContext(ctx, `INSERT INTO widgets (id) VALUES (1)`); err != nil {
+		t.Fatalf("widgets table should exist after migrate: %v", err)
+	}
+
+	statuses, err := sqlite.Status(ctx, db, set)
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	if len(statuses) != 1 || !statuses[0].Applied {
+		t.Fatalf("expected version 1 applied, got %+v", statuses)
+	}
+
+	if err := sqlite.Rollback(ctx, db, set, 1); err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+
+	if _, err := db.ExecContext(ctx, `INSERT INTO widgets (id) VALUES (1)`); err == nil {
+		t.Fatal("widgets table should not exist after rollback")
+	}
+
+	statuses, err = sqlite.Status(ctx, db, set)
+	if err != nil {
+		t.Fatalf("status after rollback: %v", err)
+	}
+	if len(statuses) != 1 || statuses[0].Applied {
+		t.Fatalf("expected version 1 pending after rollback, got %+v", statuses)
+	}
+}
@@ -0,0 +1,273 @@
+package gokart
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// stateEnvelope is the on-disk shape SaveStateVersioned writes: a schema
+// version alongside the payload, so LoadStateVersioned can tell how far
+// a file needs to be migrated before decoding it into the current type.
+type stateEnvelope struct {
+	Version int             `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// MigrationFunc transforms a state envelope's raw "data" field from one
+// schema version to the next.
+type MigrationFunc func(data json.RawMessage) (json.RawMessage, error)
+
+type migrationKey struct {
+	appName  string
+	filename string
+	from     int
+}
+
+type migrationStep struct {
+	to int
+	fn MigrationFunc
+}
+
+var migrations = struct {
+	mu sync.RWMutex
+	m  map[migrationKey]migrationStep
+}{m: make(map[migrationKey]migrationStep)}
+
+// RegisterMigration registers fn to upgrade appName/filename's state
+// envelope from schema version from to version to. LoadStateVersioned
+// walks the chain of registered migrations starting at the on-disk
+// version until it reaches the currentVersion passed to it.
+//
+// T is not read by RegisterMigration itself; it documents which type the
+// migration chain is ultimately building towards, matching the T passed
+// to the corresponding LoadStateVersioned[T] call.
+//
+// Example:
+//
+//	gokart.RegisterMigration[AppStateV2]("myapp", "state.json", 1, 2, func(data json.RawMessage) (json.RawMessage, error) {
+//	    var old AppStateV1
+//	    if err := json.Unmarshal(data, &old); err != nil {
+//	        return nil, err
+//	    }
+//	    return json.Marshal(AppStateV2{Name: old.Name, Count: int64(old.Count)})
+//	})
+func RegisterMigration[T any](appName, filename string, from, to int, fn MigrationFunc) {
+	migrations.mu.Lock()
+	defer migrations.mu.Unlock()
+	migrations.m[migrationKey{appName, filename, from}] = migrationStep{to: to, fn: fn}
+}
+
+// SaveStateVersioned saves data as a version-tagged envelope, written
+// atomically (temp file in the same directory, fsync, then os.Rename) so
+// a crash mid-write can never leave a truncated state file. File mode
+// defaults to 0644; use SaveStateVersionedMode to customize it.
+//
+// Example:
+//
+//	err := gokart.SaveStateVersioned("myapp", "state.json", 2, AppStateV2{Name: "x", Count: 3})
+func SaveStateVersioned[T any](appName, filename string, version int, data T) error {
+	return saveStateVersioned(appName, filename, version, data, 0644)
+}
+
+// SaveStateVersionedMode is SaveStateVersioned with an explicit file mode.
+func SaveStateVersionedMode[T any](appName, filename string, version int, data T, mode os.FileMode) error {
+	return saveStateVersioned(appName, filename, version, data, mode)
+}
+
+func saveStateVersioned[T any](appName, filename string, version int, data T, mode os.FileMode) error {
+	dir, err := stateDir(appName)
+	if err != nil {
+		return fmt.Errorf("get config dir: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("create config dir: %w", err)
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("marshal state: %w", err)
+	}
+
+	content, err := json.MarshalIndent(stateEnvelope{Version: version, Data: raw}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal envelope: %w", err)
+	}
+
+	return atomicWriteFile(filepath.Join(dir, filename), content, mode)
+}
+
+// LoadStateVersioned loads appName/filename's state envelope and migrates
+// it forward to currentVersion using the chain of MigrationFuncs
+// registered with RegisterMigration. Files written before versioning was
+// introduced (plain, unwrapped JSON) are treated as schema version 0.
+//
+// The returned bool reports whether any migration ran, so the caller can
+// decide to rewrite the file at the new version (typically after calling
+// StateBackup first).
+//
+// Returns os.ErrNotExist if the file doesn't exist, matching LoadState.
+//
+// Example:
+//
+//	state, upgraded, err := gokart.LoadStateVersioned[AppStateV2]("myapp", "state.json", 2)
+//	if err != nil && !errors.Is(err, os.ErrNotExist) {
+//	    return err
+//	}
+//	if upgraded {
+//	    gokart.StateBackup("myapp", "state.json", 5)
+//	    gokart.SaveStateVersioned("myapp", "state.json", 2, state)
+//	}
+func LoadStateVersioned[T any](appName, filename string, currentVersion int) (T, bool, error) {
+	var zero T
+
+	path := StatePath(appName, filename)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return zero, false, os.ErrNotExist
+		}
+		return zero, false, fmt.Errorf("read state file: %w", err)
+	}
+
+	version, data, err := unwrapEnvelope(content)
+	if err != nil {
+		return zero, false, fmt.Errorf("parse state envelope: %w", err)
+	}
+	onDiskVersion := version
+
+	for version < currentVersion {
+		migrations.mu.RLock()
+		step, ok := migrations.m[migrationKey{appName, filename, version}]
+		migrations.mu.RUnlock()
+		if !ok {
+			return zero, false, fmt.Errorf("no migration registered for %s/%s from version %d", appName, filename, version)
+		}
+
+		data, err = step.fn(data)
+		if err != nil {
+			return zero, false, fmt.Errorf("migrate %s/%s from version %d: %w", appName, filename, version, err)
+		}
+		version = step.to
+	}
+
+	var result T
+	if err := json.Unmarshal(data, &result); err != nil {
+		return zero, false, fmt.Errorf("unmarshal state: %w", err)
+	}
+
+	return result, version != onDiskVersion, nil
+}
+
+// unwrapEnvelope reads content as a stateEnvelope, falling back to
+// treating the whole document as version-0 data when it isn't one (the
+// back-compat path for files written by plain SaveState).
+func unwrapEnvelope(content []byte) (int, json.RawMessage, error) {
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(content, &probe); err != nil {
+		return 0, nil, err
+	}
+
+	versionRaw, hasVersion := probe["version"]
+	dataRaw, hasData := probe["data"]
+	if !hasVersion || !hasData {
+		return 0, content, nil
+	}
+
+	var version int
+	if err := json.Unmarshal(versionRaw, &version); err != nil {
+		return 0, nil, fmt.Errorf("parse version: %w", err)
+	}
+	return version, dataRaw, nil
+}
+
+// StateBackup copies appName/filename's current state file to a
+// timestamped backup in the same directory, then prunes older backups
+// beyond the last keep. Call this before overwriting a file with
+// migrated state, so a bad migration can be recovered from. A missing
+// state file is not an error, since there's nothing to back up yet.
+func StateBackup(appName, filename string, keep int) error {
+	path := StatePath(appName, filename)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("read state file: %w", err)
+	}
+
+	backupPath := fmt.Sprintf("%s.bak.%d", path, time.Now().UnixNano())
+	if err := os.WriteFile(backupPath, content, 0644); err != nil {
+		return fmt.Errorf("write backup: %w", err)
+	}
+
+	return pruneBackups(path, keep)
+}
+
+func pruneBackups(path string, keep int) error {
+	dir := filepath.Dir(path)
+	prefix := filepath.Base(path) + ".bak."
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("read state dir: %w", err)
+	}
+
+	var backups []string
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), prefix) {
+			backups = append(backups, e.Name())
+		}
+	}
+	sort.Strings(backups) // nanosecond timestamp suffix sorts lexically = chronologically
+
+	if len(backups) <= keep {
+		return nil
+	}
+	for _, name := range backups[:len(backups)-keep] {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil {
+			return fmt.Errorf("remove old backup %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// atomicWriteFile writes content to path by first writing to a temp file
+// in the same directory (so the final rename is on the same filesystem),
+// fsyncing it, then renaming it over path. A crash at any point before
+// the rename leaves the original file untouched.
+func atomicWriteFile(path string, content []byte, mode os.FileMode) error {
+	dir := filepath.Dir(path)
+
+	tmp, err := os.CreateTemp(dir, ".tmp-"+filepath.Base(path)+"-*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once the rename below succeeds
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write temp file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("fsync temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp file: %w", err)
+	}
+	if err := os.Chmod(tmpPath, mode); err != nil {
+		return fmt.Errorf("chmod temp file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("rename temp file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package gokart_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotcommander/gokart"
+)
+
+type stateV1 struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type stateV2 struct {
+	Name  string   `json:"name"`
+	Count int64    `json:"count"`
+	Tags  []string `json:"tags"`
+}
+
+func cleanupState(t *testing.T, appName, filename string) {
+	t.Helper()
+	t.Cleanup(func() {
+		dir := filepath.Dir(gokart.StatePath(appName, filename))
+		os.RemoveAll(dir)
+	})
+}
+
+func TestSaveAndLoadStateVersioned(t *testing.T) {
+	t.Parallel()
+
+	appName := "gokart-test-" + t.Name()
+	filename := "state.json"
+	cleanupState(t, appName, filename)
+
+	original := stateV2{Name: "test", Count: 42, Tags: []string{"a", "b"}}
+	if err := gokart.SaveStateVersioned(appName, filename, 2, original); err != nil {
+		t.Fatalf("SaveStateVersioned failed: %v", err)
+	}
+
+	loaded, upgraded, err := gokart.LoadStateVersioned[stateV2](appName, filename, 2)
+	if err != nil {
+		t.Fatalf("LoadStateVersioned failed: %v", err)
+	}
+	if upgraded {
+		t.Error("expected upgraded=false when on-disk version already matches currentVersion")
+	}
+	if loaded.Name != original.Name || loaded.Count != original.Count || len(loaded.Tags) != len(original.Tags) {
+		t.Errorf("loaded = %+v, want %+v", loaded, original)
+	}
+}
+
+func TestLoadStateVersioned_MigratesLegacyUnversionedFile(t *testing.T) {
+	t.Parallel()
+
+	appName := "gokart-test-" + t.Name()
+	filename := "state.json"
+	cleanupState(t, appName, filename)
+
+	// Simulate a file written by the pre-versioning SaveState: plain,
+	// unwrapped JSON with no "version"/"data" envelope.
+	if err := gokart.SaveState(appName, filename, stateV1{Name: "legacy", Count: 7}); err != nil {
+		t.Fatalf("SaveState failed: %v", err)
+	}
+
+	gokart.RegisterMigration[stateV2](appName, filename, 0, 2, func(data json.RawMessage) (json.RawMessage, error) {
+		var old stateV1
+		if err := json.Unmarshal(data, &old); err != nil {
+			return nil, err
+		}
+		return json.Marshal(stateV2{Name: old.Name, Count: int64(old.Count)})
+	})
+
+	loaded, upgraded, err := gokart.LoadStateVersioned[stateV2](appName, filename, 2)
+	if err != nil {
+		t.Fatalf("LoadStateVersioned failed: %v", err)
+	}
+	if !upgraded {
+		t.Error("expected upgraded=true when migrating from an unversioned file")
+	}
+	if loaded.Name != "legacy" || loaded.Count != 7 {
+		t.Errorf("loaded = %+v, want Name=legacy Count=7", loaded)
+	}
+}
+
+func TestLoadStateVersioned_NoMigrationRegistered(t *testing.T) {
+	t.Parallel()
+
+	appName := "gokart-test-" + t.Name()
+	filename := "state.json"
+	cleanupState(t, appName, filename)
+
+	if err := gokart.SaveStateVersioned(appName, filename, 1, stateV1{Name: "x"}); err != nil {
+		t.Fatalf("SaveStateVersioned failed: %v", err)
+	}
+
+	_, _, err := gokart.LoadStateVersioned[stateV2](appName, filename, 5)
+	if err == nil {
+		t.Fatal("expected an error when no migration is registered from version 1 to 5")
+	}
+}
+
+func TestStateBackup_PrunesOldBackups(t *testing.T) {
+	t.Parallel()
+
+	appName := "gokart-test-" + t.Name()
+	filename := "state.json"
+	cleanupState(t, appName, filename)
+
+	if err := gokart.SaveStateVersioned(appName, filename, 1, stateV1{Name: "x"}); err != nil {
+		t.Fatalf("SaveStateVersioned failed: %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if err := gokart.StateBackup(appName, filename, 2); err != nil {
+			t.Fatalf("StateBackup failed: %v", err)
+		}
+	}
+
+	dir := filepath.Dir(gokart.StatePath(appName, filename))
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+
+	var backups int
+	for _, e := range entries {
+		if filepath.Ext(e.Name()) != ".json" {
+			backups++
+		}
+	}
+	if backups > 2 {
+		t.Errorf("expected at most 2 backups to survive pruning, got %d", backups)
+	}
+}
+
+func TestLoadStateVersioned_NotFound(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := gokart.LoadStateVersioned[stateV1]("nonexistent-app-xyz", "missing.json", 1)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected os.ErrNotExist, got %v", err)
+	}
+}
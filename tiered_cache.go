@@ -0,0 +1,259 @@
+package gokart
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/sync/singleflight"
+)
+
+// ErrNotFound is returned by a Cacher's loader function to indicate the
+// underlying value genuinely doesn't exist, as opposed to a transient
+// error. TieredCache negative-caches this result for NegativeTTL so a
+// thundering herd of misses for a missing key doesn't hammer the origin.
+var ErrNotFound = errors.New("gokart: not found")
+
+// Cacher is satisfied by both *Cache and *TieredCache, so callers can
+// swap in the tiered implementation without touching call sites.
+type Cacher interface {
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key, value string, ttl time.Duration) error
+	GetJSON(ctx context.Context, key string, dest interface{}) error
+	SetJSON(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+	Remember(ctx context.Context, key string, ttl time.Duration, fn func() (interface{}, error)) (string, error)
+	RememberJSON(ctx context.Context, key string, ttl time.Duration, dest interface{}, fn func() (interface{}, error)) error
+	Stats() CacheStats
+}
+
+// CacheStats tracks per-tier hit/miss/load/error counters.
+type CacheStats struct {
+	Hits   int64
+	Misses int64
+	Loads  int64
+	Errors int64
+}
+
+type localEntry struct {
+	value    string
+	negative bool
+	expires  time.Time
+}
+
+func (e localEntry) expired() bool {
+	return time.Now().After(e.expires)
+}
+
+// TieredCacheConfig configures NewTieredCache.
+type TieredCacheConfig struct {
+	// Redis is the origin tier. Required.
+	Redis *Cache
+
+	// LocalSize is the in-process LRU's max entry count. Default: 10000.
+	LocalSize int
+
+	// LocalTTL is how long a positive entry stays fresh in the local
+	// tier before falling back to Redis. Default: 30 seconds.
+	LocalTTL time.Duration
+
+	// NegativeTTL is how long a "not found" result is cached, in both
+	// tiers, before the loader is retried. Default: 5 seconds.
+	NegativeTTL time.Duration
+}
+
+// TieredCache fronts a *Cache (Redis) with an in-process LRU layer and
+// singleflight-coalesced loads, mirroring the go-zero core/stores/cache
+// design: a Cache interface, a Stat struct, and a shared-calls barrier.
+type TieredCache struct {
+	redis       *Cache
+	local       *lru.Cache[string, localEntry]
+	group       singleflight.Group
+	localTTL    time.Duration
+	negativeTTL time.Duration
+
+	hits, misses, loads, errs int64
+}
+
+// NewTieredCache builds a two-tier cache in front of cfg.Redis.
+//
+// Example:
+//
+//	tiered, err := gokart.NewTieredCache(gokart.TieredCacheConfig{Redis: cache})
+//	user, err := tiered.Remember(ctx, "user:123", time.Hour, func() (interface{}, error) {
+//	    u, err := db.GetUser(ctx, 123)
+//	    if errors.Is(err, sql.ErrNoRows) {
+//	        return nil, gokart.ErrNotFound
+//	    }
+//	    return u, err
+//	})
+func NewTieredCache(cfg TieredCacheConfig) (*TieredCache, error) {
+	if cfg.LocalSize <= 0 {
+		cfg.LocalSize = 10000
+	}
+	if cfg.LocalTTL <= 0 {
+		cfg.LocalTTL = 30 * time.Second
+	}
+	if cfg.NegativeTTL <= 0 {
+		cfg.NegativeTTL = 5 * time.Second
+	}
+
+	local, err := lru.New[string, localEntry](cfg.LocalSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TieredCache{
+		redis:       cfg.Redis,
+		local:       local,
+		localTTL:    cfg.LocalTTL,
+		negativeTTL: cfg.NegativeTTL,
+	}, nil
+}
+
+// Get retrieves a string value, checking the local tier before Redis.
+func (t *TieredCache) Get(ctx context.Context, key string) (string, error) {
+	if e, ok := t.local.Get(key); ok && !e.expired() {
+		atomic.AddInt64(&t.hits, 1)
+		if e.negative {
+			return "", ErrNotFound
+		}
+		return e.value, nil
+	}
+
+	val, err := t.redis.Get(ctx, key)
+	if IsNil(err) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		atomic.AddInt64(&t.errs, 1)
+		return "", err
+	}
+
+	atomic.AddInt64(&t.misses, 1)
+	if val == negativeSentinel {
+		t.local.Add(key, localEntry{negative: true, expires: time.Now().Add(t.negativeTTL)})
+		return "", ErrNotFound
+	}
+
+	t.local.Add(key, localEntry{value: val, expires: time.Now().Add(t.localTTL)})
+	return val, nil
+}
+
+// Set stores a value in both tiers.
+func (t *TieredCache) Set(ctx context.Context, key, value string, ttl time.Duration) error {
+	if err := t.redis.Set(ctx, key, value, ttl); err != nil {
+		return err
+	}
+	t.local.Add(key, localEntry{value: value, expires: time.Now().Add(t.localTTL)})
+	return nil
+}
+
+// GetJSON retrieves and unmarshals a JSON value.
+func (t *TieredCache) GetJSON(ctx context.Context, key string, dest interface{}) error {
+	val, err := t.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(val), dest)
+}
+
+// SetJSON marshals and stores a value as JSON.
+func (t *TieredCache) SetJSON(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return t.Set(ctx, key, string(data), ttl)
+}
+
+// Remember gets a value or loads it via fn, coalescing concurrent misses
+// for the same key into a single call to fn (singleflight), and
+// negative-caching ErrNotFound for NegativeTTL.
+func (t *TieredCache) Remember(ctx context.Context, key string, ttl time.Duration, fn func() (interface{}, error)) (string, error) {
+	if val, err := t.Get(ctx, key); err == nil {
+		return val, nil
+	} else if !errors.Is(err, ErrNotFound) {
+		return "", err
+	}
+	if e, ok := t.local.Get(key); ok && e.negative && !e.expired() {
+		return "", ErrNotFound
+	}
+
+	v, err, _ := t.group.Do(key, func() (interface{}, error) {
+		atomic.AddInt64(&t.loads, 1)
+
+		result, err := fn()
+		if errors.Is(err, ErrNotFound) {
+			t.local.Add(key, localEntry{negative: true, expires: time.Now().Add(t.negativeTTL)})
+			_ = t.redis.Set(ctx, key, negativeSentinel, t.negativeTTL)
+			return "", ErrNotFound
+		}
+		if err != nil {
+			atomic.AddInt64(&t.errs, 1)
+			return "", err
+		}
+
+		strVal, err := stringifyRememberResult(result)
+		if err != nil {
+			return "", err
+		}
+		if err := t.Set(ctx, key, strVal, ttl); err != nil {
+			return "", err
+		}
+		return strVal, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return v.(string), nil
+}
+
+// RememberJSON is Remember, but marshals/unmarshals through JSON so type
+// information survives the round trip.
+func (t *TieredCache) RememberJSON(ctx context.Context, key string, ttl time.Duration, dest interface{}, fn func() (interface{}, error)) error {
+	val, err := t.Remember(ctx, key, ttl, fn)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(val), dest)
+}
+
+// Stats returns a snapshot of hit/miss/load/error counters.
+func (t *TieredCache) Stats() CacheStats {
+	return CacheStats{
+		Hits:   atomic.LoadInt64(&t.hits),
+		Misses: atomic.LoadInt64(&t.misses),
+		Loads:  atomic.LoadInt64(&t.loads),
+		Errors: atomic.LoadInt64(&t.errs),
+	}
+}
+
+// negativeSentinel is stored in Redis (not returned to callers) to mark a
+// key as known-absent across processes sharing the same Redis tier.
+const negativeSentinel = "\x00gokart:negative"
+
+func stringifyRememberResult(result interface{}) (string, error) {
+	switch v := result.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+}
+
+// Stats on *Cache satisfies the Cacher interface for code that doesn't
+// care whether it's talking to the single-tier or tiered implementation.
+// A plain *Cache keeps no counters, so Stats always returns a zero
+// CacheStats.
+func (c *Cache) Stats() CacheStats {
+	return CacheStats{}
+}
@@ -0,0 +1,47 @@
+package gokart_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dotcommander/gokart"
+)
+
+// TestTieredCacheGet_NegativeSentinelFromRedis is the cross-process case:
+// one TieredCache negative-caches a miss via Remember, and a second
+// TieredCache instance (sharing only the Redis tier, with an empty local
+// tier of its own) reads the key directly through Get.
+func TestTieredCacheGet_NegativeSentinelFromRedis(t *testing.T) {
+	t.Parallel()
+
+	cache := newTestCache(t)
+	ctx := context.Background()
+
+	writer, err := gokart.NewTieredCache(gokart.TieredCacheConfig{Redis: cache, NegativeTTL: time.Minute})
+	if err != nil {
+		t.Fatalf("NewTieredCache (writer): %v", err)
+	}
+
+	if _, err := writer.Remember(ctx, "missing:1", time.Minute, func() (interface{}, error) {
+		return nil, gokart.ErrNotFound
+	}); !errors.Is(err, gokart.ErrNotFound) {
+		t.Fatalf("Remember: expected ErrNotFound, got %v", err)
+	}
+
+	reader, err := gokart.NewTieredCache(gokart.TieredCacheConfig{Redis: cache, NegativeTTL: time.Minute})
+	if err != nil {
+		t.Fatalf("NewTieredCache (reader): %v", err)
+	}
+
+	if _, err := reader.Get(ctx, "missing:1"); !errors.Is(err, gokart.ErrNotFound) {
+		t.Fatalf("Get: expected ErrNotFound, got value/err %v", err)
+	}
+
+	// The now-local negative entry should also short-circuit without
+	// touching Redis again.
+	if _, err := reader.Get(ctx, "missing:1"); !errors.Is(err, gokart.ErrNotFound) {
+		t.Fatalf("Get (local hit): expected ErrNotFound, got %v", err)
+	}
+}
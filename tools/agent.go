@@ -0,0 +1,150 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dotcommander/gokart"
+)
+
+// ErrMaxSteps is returned by Agent.Run/Stream when MaxSteps tool-call
+// round trips elapse without the model producing a final message.
+var ErrMaxSteps = fmt.Errorf("tools: exceeded max step budget without a final response")
+
+// Agent drives a chat completion loop against a gokart.Provider,
+// automatically dispatching tool calls through a Registry and feeding
+// their results back to the model until it emits a final assistant
+// message (no further tool calls) or MaxSteps round trips elapse.
+type Agent struct {
+	Provider gokart.Provider
+	Registry *Registry
+
+	// MaxSteps bounds the number of tool-call round trips. Default: 5.
+	MaxSteps int
+}
+
+// NewAgent returns an Agent using the default (package-level) Registry.
+func NewAgent(provider gokart.Provider) *Agent {
+	return &Agent{Provider: provider, Registry: defaultRegistry, MaxSteps: 5}
+}
+
+func (a *Agent) maxSteps() int {
+	if a.MaxSteps > 0 {
+		return a.MaxSteps
+	}
+	return 5
+}
+
+func (a *Agent) registry() *Registry {
+	if a.Registry != nil {
+		return a.Registry
+	}
+	return defaultRegistry
+}
+
+// Run executes the tool-resolution loop and returns the model's final
+// response. req.Tools is overwritten with the Agent's Registry schemas.
+//
+// Example:
+//
+//	agent := tools.NewAgent(provider)
+//	resp, err := agent.Run(ctx, gokart.CompletionRequest{
+//	    Model:    "gpt-4o-mini",
+//	    Messages: []gokart.Message{{Role: gokart.RoleUser, Content: "what's the weather in Paris?"}},
+//	})
+func (a *Agent) Run(ctx context.Context, req gokart.CompletionRequest) (gokart.CompletionResponse, error) {
+	req.Tools = a.registry().Schemas()
+	messages := append([]gokart.Message(nil), req.Messages...)
+
+	for step := 0; step < a.maxSteps(); step++ {
+		req.Messages = messages
+
+		resp, err := a.Provider.Complete(ctx, req)
+		if err != nil {
+			return gokart.CompletionResponse{}, err
+		}
+
+		if len(resp.ToolCalls) == 0 {
+			return resp, nil
+		}
+
+		messages = append(messages, gokart.Message{Role: gokart.RoleAssistant, Content: resp.Content, ToolCalls: resp.ToolCalls})
+
+		for _, call := range resp.ToolCalls {
+			result, err := a.registry().Dispatch(ctx, call)
+			content, marshalErr := toolResultContent(result, err)
+			if marshalErr != nil {
+				return gokart.CompletionResponse{}, marshalErr
+			}
+			messages = append(messages, gokart.Message{
+				Role:       gokart.RoleTool,
+				Content:    content,
+				ToolCallID: call.ID,
+			})
+		}
+	}
+
+	return gokart.CompletionResponse{}, ErrMaxSteps
+}
+
+// Stream runs the same tool-resolution loop as Run, but streams the
+// final step's content deltas through onChunk once the model stops
+// requesting tools. Intermediate tool-resolution steps are not
+// streamed, since their content is discarded as soon as tool calls are
+// dispatched.
+func (a *Agent) Stream(ctx context.Context, req gokart.CompletionRequest, onChunk func(gokart.StreamChunk) error) error {
+	req.Tools = a.registry().Schemas()
+	messages := append([]gokart.Message(nil), req.Messages...)
+
+	for step := 0; step < a.maxSteps(); step++ {
+		req.Messages = messages
+
+		resp, err := a.Provider.Complete(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		if len(resp.ToolCalls) == 0 {
+			return a.Provider.Stream(ctx, req, onChunk)
+		}
+
+		messages = append(messages, gokart.Message{Role: gokart.RoleAssistant, Content: resp.Content, ToolCalls: resp.ToolCalls})
+
+		for _, call := range resp.ToolCalls {
+			result, dispatchErr := a.registry().Dispatch(ctx, call)
+			content, err := toolResultContent(result, dispatchErr)
+			if err != nil {
+				return err
+			}
+			messages = append(messages, gokart.Message{
+				Role:       gokart.RoleTool,
+				Content:    content,
+				ToolCallID: call.ID,
+			})
+		}
+	}
+
+	return ErrMaxSteps
+}
+
+// toolResultContent encodes a tool's result (or error) as the string
+// content of a RoleTool message, the same convention OpenAI's function
+// calling API uses: errors are reported back to the model as a JSON
+// object instead of aborting the conversation, since the model can
+// often recover (retry with different arguments, apologize, etc).
+func toolResultContent(result interface{}, err error) (string, error) {
+	if err != nil {
+		data, marshalErr := json.Marshal(map[string]string{"error": err.Error()})
+		if marshalErr != nil {
+			return "", marshalErr
+		}
+		return string(data), nil
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("tools: marshal result: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,129 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/dotcommander/gokart"
+)
+
+// Handler dispatches a validated, decoded tool call to its Go function
+// and returns a JSON-encodable result.
+type Handler func(ctx context.Context, rawArgs json.RawMessage) (interface{}, error)
+
+type entry struct {
+	schema  gokart.ToolSchema
+	handler Handler
+}
+
+// Registry holds tool definitions and dispatches incoming ToolCalls to
+// their registered Go functions.
+type Registry struct {
+	mu    sync.RWMutex
+	tools map[string]entry
+}
+
+// NewRegistry returns an empty Registry. Use Register to populate it.
+func NewRegistry() *Registry {
+	return &Registry{tools: make(map[string]entry)}
+}
+
+// defaultRegistry backs the package-level Register/Schemas/Dispatch
+// helpers, for the common case of one process-wide tool set.
+var defaultRegistry = NewRegistry()
+
+// Register adds fn to the default Registry under name, generating its
+// JSON schema from Args's struct tags. See RegisterOn to use a
+// non-default Registry (e.g. one scoped per Agent).
+//
+// Example:
+//
+//	type WeatherArgs struct {
+//	    Location string `json:"location" jsonschema:"required,description=City name"`
+//	}
+//	type WeatherResult struct{ TempF int }
+//
+//	tools.Register("get_weather", "Get the current weather for a city", func(ctx context.Context, args WeatherArgs) (WeatherResult, error) {
+//	    return WeatherResult{TempF: 72}, nil
+//	})
+func Register[Args any, Result any](name, description string, fn func(ctx context.Context, args Args) (Result, error)) {
+	RegisterOn(defaultRegistry, name, description, fn)
+}
+
+// RegisterOn adds fn to r under name. See Register for the common,
+// default-registry case.
+func RegisterOn[Args any, Result any](r *Registry, name, description string, fn func(ctx context.Context, args Args) (Result, error)) {
+	var zero Args
+	schema := schemaOf(reflect.TypeOf(zero))
+
+	handler := func(ctx context.Context, rawArgs json.RawMessage) (interface{}, error) {
+		var argMap map[string]interface{}
+		if len(rawArgs) > 0 {
+			if err := json.Unmarshal(rawArgs, &argMap); err != nil {
+				return nil, fmt.Errorf("tool %q: invalid arguments JSON: %w", name, err)
+			}
+		}
+		if err := validateRequired(schema, argMap); err != nil {
+			return nil, fmt.Errorf("tool %q: %w", name, err)
+		}
+
+		var args Args
+		if len(rawArgs) > 0 {
+			if err := json.Unmarshal(rawArgs, &args); err != nil {
+				return nil, fmt.Errorf("tool %q: decode arguments: %w", name, err)
+			}
+		}
+
+		return fn(ctx, args)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tools[name] = entry{
+		schema: gokart.ToolSchema{
+			Name:        name,
+			Description: description,
+			Parameters:  schema,
+		},
+		handler: handler,
+	}
+}
+
+// Schemas returns the registered tools as gokart.ToolSchema, ready to
+// assign to gokart.CompletionRequest.Tools.
+func (r *Registry) Schemas() []gokart.ToolSchema {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schemas := make([]gokart.ToolSchema, 0, len(r.tools))
+	for _, e := range r.tools {
+		schemas = append(schemas, e.schema)
+	}
+	return schemas
+}
+
+// Schemas returns the default Registry's tools.
+func Schemas() []gokart.ToolSchema {
+	return defaultRegistry.Schemas()
+}
+
+// Dispatch checks that call.Arguments holds every property the
+// registered schema marks required (types and enums are not checked),
+// decodes them into the tool's Args type, and runs its handler.
+func (r *Registry) Dispatch(ctx context.Context, call gokart.ToolCall) (interface{}, error) {
+	r.mu.RLock()
+	e, ok := r.tools[call.Name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("tool %q is not registered", call.Name)
+	}
+	return e.handler(ctx, json.RawMessage(call.Arguments))
+}
+
+// Dispatch runs call against the default Registry.
+func Dispatch(ctx context.Context, call gokart.ToolCall) (interface{}, error) {
+	return defaultRegistry.Dispatch(ctx, call)
+}
@@ -0,0 +1,138 @@
+// Package tools turns typed Go functions into LLM tool/function-calling
+// definitions: Register a function once, and its argument struct's JSON
+// schema, argument validation, and dispatch are all derived from
+// reflection instead of hand-written maps.
+package tools
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// schemaOf builds a JSON-schema "object" description of t's fields,
+// reading the `json` tag for the property name and the `jsonschema` tag
+// for "required" and "description=...".
+func schemaOf(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	properties := map[string]interface{}{}
+	var required []string
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, omit := jsonFieldName(field)
+		if omit {
+			continue
+		}
+
+		prop, isRequired := schemaForField(field)
+		properties[name] = prop
+		if isRequired {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func jsonFieldName(field reflect.StructField) (name string, omit bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	name = field.Name
+	if tag != "" {
+		parts := strings.Split(tag, ",")
+		if parts[0] != "" {
+			name = parts[0]
+		}
+	}
+	return name, false
+}
+
+func schemaForField(field reflect.StructField) (prop map[string]interface{}, required bool) {
+	prop = jsonSchemaType(field.Type)
+
+	tag := field.Tag.Get("jsonschema")
+	for _, part := range strings.Split(tag, ",") {
+		part = strings.TrimSpace(part)
+		switch {
+		case part == "required":
+			required = true
+		case strings.HasPrefix(part, "description="):
+			prop["description"] = strings.TrimPrefix(part, "description=")
+		case strings.HasPrefix(part, "enum="):
+			values := strings.Split(strings.TrimPrefix(part, "enum="), "|")
+			enum := make([]interface{}, len(values))
+			for i, v := range values {
+				enum[i] = v
+			}
+			prop["enum"] = enum
+		}
+	}
+	return prop, required
+}
+
+func jsonSchemaType(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{
+			"type":  "array",
+			"items": jsonSchemaType(t.Elem()),
+		}
+	case reflect.Struct:
+		return schemaOf(t)
+	case reflect.Map:
+		return map[string]interface{}{"type": "object"}
+	default:
+		return map[string]interface{}{"type": "string"}
+	}
+}
+
+// requiredFields returns schema's "required" list as a string slice, for
+// validating incoming tool-call arguments before dispatch.
+func requiredFields(schema map[string]interface{}) []string {
+	raw, ok := schema["required"].([]string)
+	if !ok {
+		return nil
+	}
+	return raw
+}
+
+// validateRequired reports a descriptive error if any of schema's
+// required properties are absent from args.
+func validateRequired(schema map[string]interface{}, args map[string]interface{}) error {
+	for _, field := range requiredFields(schema) {
+		if _, ok := args[field]; !ok {
+			return fmt.Errorf("missing required argument %q", field)
+		}
+	}
+	return nil
+}
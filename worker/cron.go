@@ -0,0 +1,202 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/robfig/cron/v3"
+)
+
+// cronAdvisoryLockKey is the pg_try_advisory_lock key cron schedulers
+// contend for, so that when multiple replicas run Cron concurrently
+// only one of them actually enqueues jobs on any given tick.
+const cronAdvisoryLockKey = 7734001
+
+// cronSchemaSQL creates the table Cron uses to persist each schedule's
+// next run time in Postgres rather than in replica-local memory — a
+// replica that has never won the leader lock must still know not to
+// re-fire everything due since its own startup once it finally does.
+const cronSchemaSQL = `
+CREATE TABLE IF NOT EXISTS gokart_cron_schedules (
+	kind        TEXT PRIMARY KEY,
+	next_run_at TIMESTAMPTZ NOT NULL
+);
+`
+
+// MigrateCron creates the gokart_cron_schedules table if it doesn't
+// already exist. Call it once at startup before Cron, alongside Migrate.
+func MigrateCron(ctx context.Context, pool *pgxpool.Pool) error {
+	if _, err := pool.Exec(ctx, cronSchemaSQL); err != nil {
+		return fmt.Errorf("worker: migrate cron schedules: %w", err)
+	}
+	return nil
+}
+
+// CronSchedule is one recurring job: Payload is enqueued under Kind
+// whenever Spec (a standard 5-field cron expression) is due.
+type CronSchedule struct {
+	Spec    string
+	Kind    string
+	Payload any
+}
+
+// CronConfig configures Cron.
+type CronConfig struct {
+	// Pool is the pgxpool schedules are enqueued into and the leader
+	// election advisory lock is taken on.
+	Pool *pgxpool.Pool
+
+	// CheckInterval is how often schedules are checked against the
+	// current time. Default: 30s. Keep it well under the shortest Spec's
+	// period so due jobs aren't missed.
+	CheckInterval time.Duration
+
+	// Logger receives leader-election and enqueue events. Default:
+	// slog.Default().
+	Logger *slog.Logger
+}
+
+func (c CronConfig) withDefaults() CronConfig {
+	if c.CheckInterval == 0 {
+		c.CheckInterval = 30 * time.Second
+	}
+	if c.Logger == nil {
+		c.Logger = slog.Default()
+	}
+	return c
+}
+
+// Cron evaluates schedules every CheckInterval and enqueues any that are
+// due, blocking until ctx is canceled. Before evaluating, it takes a
+// Postgres advisory lock (pg_try_advisory_lock), held only for the
+// duration of that tick's check, so that running Cron on every replica
+// of a service is safe: whichever replica wins the lock on a given tick
+// is the one that enqueues, and the rest skip it. Each schedule's next
+// run time is persisted in gokart_cron_schedules (see MigrateCron)
+// rather than kept in replica-local memory, so a replica that has never
+// won the lock doesn't re-fire everything due since its own startup the
+// first time it does — cron survives restarts and scales out without
+// duplicate firing.
+//
+// Example:
+//
+//	err := worker.Cron(ctx, worker.CronConfig{Pool: pool}, []worker.CronSchedule{
+//	    {Spec: "0 * * * *", Kind: "hourly_report", Payload: nil},
+//	    {Spec: "*/15 * * * *", Kind: "sync_inventory", Payload: nil},
+//	})
+func Cron(ctx context.Context, cfg CronConfig, schedules []CronSchedule) error {
+	cfg = cfg.withDefaults()
+
+	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+	specs := make([]cron.Schedule, len(schedules))
+	for i, sch := range schedules {
+		parsed, err := parser.Parse(sch.Spec)
+		if err != nil {
+			return fmt.Errorf("worker: parse cron spec %q for %q: %w", sch.Spec, sch.Kind, err)
+		}
+		specs[i] = parsed
+	}
+
+	if err := seedNextRun(ctx, cfg.Pool, schedules, specs); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			cfg.runDueSchedules(ctx, schedules, specs)
+		}
+	}
+}
+
+// seedNextRun records each schedule's first next-run time, leaving any
+// row already persisted from a previous run untouched.
+func seedNextRun(ctx context.Context, pool *pgxpool.Pool, schedules []CronSchedule, specs []cron.Schedule) error {
+	now := time.Now()
+	for i, sch := range schedules {
+		if _, err := pool.Exec(ctx,
+			`INSERT INTO gokart_cron_schedules (kind, next_run_at) VALUES ($1, $2) ON CONFLICT (kind) DO NOTHING`,
+			sch.Kind, specs[i].Next(now),
+		); err != nil {
+			return fmt.Errorf("worker: seed cron schedule %q: %w", sch.Kind, err)
+		}
+	}
+	return nil
+}
+
+// runDueSchedules takes the leader-election lock, enqueues every schedule
+// whose persisted next_run_at has passed, advances it in Postgres, and
+// releases the lock.
+func (cfg CronConfig) runDueSchedules(ctx context.Context, schedules []CronSchedule, specs []cron.Schedule) {
+	leader, release, err := tryAcquireLeader(ctx, cfg.Pool)
+	if err != nil {
+		cfg.Logger.Error("worker: cron leader check failed", "error", err)
+		return
+	}
+	if !leader {
+		return
+	}
+	defer release()
+
+	now := time.Now()
+	for i, sch := range schedules {
+		var nextRunAt time.Time
+		if err := cfg.Pool.QueryRow(ctx,
+			`SELECT next_run_at FROM gokart_cron_schedules WHERE kind = $1`, sch.Kind,
+		).Scan(&nextRunAt); err != nil {
+			cfg.Logger.Error("worker: cron read schedule state failed", "kind", sch.Kind, "error", err)
+			continue
+		}
+		if now.Before(nextRunAt) {
+			continue
+		}
+
+		if _, err := Enqueue(ctx, cfg.Pool, sch.Kind, sch.Payload, EnqueueOptions{}); err != nil {
+			cfg.Logger.Error("worker: cron enqueue failed", "kind", sch.Kind, "spec", sch.Spec, "error", err)
+		} else {
+			cfg.Logger.Info("worker: cron enqueued", "kind", sch.Kind, "spec", sch.Spec)
+		}
+
+		if _, err := cfg.Pool.Exec(ctx,
+			`UPDATE gokart_cron_schedules SET next_run_at = $2 WHERE kind = $1`,
+			sch.Kind, specs[i].Next(now),
+		); err != nil {
+			cfg.Logger.Error("worker: cron persist next run failed", "kind", sch.Kind, "error", err)
+		}
+	}
+}
+
+// tryAcquireLeader attempts to take the cron advisory lock on a
+// dedicated connection, returning whether it succeeded and a release
+// func that must be called (even on failure to acquire, where it's a
+// no-op) once the caller is done.
+func tryAcquireLeader(ctx context.Context, pool *pgxpool.Pool) (bool, func(), error) {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return false, func() {}, fmt.Errorf("worker: acquire connection for leader check: %w", err)
+	}
+
+	var acquired bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", cronAdvisoryLockKey).Scan(&acquired); err != nil {
+		conn.Release()
+		return false, func() {}, fmt.Errorf("worker: try advisory lock: %w", err)
+	}
+	if !acquired {
+		conn.Release()
+		return false, func() {}, nil
+	}
+
+	release := func() {
+		conn.Exec(ctx, "SELECT pg_advisory_unlock($1)", cronAdvisoryLockKey)
+		conn.Release()
+	}
+	return true, release, nil
+}
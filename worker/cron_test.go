@@ -0,0 +1,108 @@
+package worker
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/robfig/cron/v3"
+)
+
+// requireTestPool returns a pool for TEST_DATABASE_URL, skipping the test
+// if it isn't set — there's no in-memory Postgres available in this repo's
+// test environment.
+func requireTestPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+	pool, err := pgxpool.New(context.Background(), dsn)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+// TestRunDueSchedules_NoDuplicateAcrossReplicas is the regression case for
+// a replica that has never won the leader lock: its next-run state must
+// come from Postgres, not a zero-value in-memory slice, or the first tick
+// it wins would re-fire everything "due" since process startup.
+func TestRunDueSchedules_NoDuplicateAcrossReplicas(t *testing.T) {
+	pool := requireTestPool(t)
+	ctx := context.Background()
+
+	if err := Migrate(ctx, pool); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if err := MigrateCron(ctx, pool); err != nil {
+		t.Fatalf("migrate cron: %v", err)
+	}
+
+	kind := "cron_test_job"
+	if _, err := pool.Exec(ctx, `DELETE FROM gokart_cron_schedules WHERE kind = $1`, kind); err != nil {
+		t.Fatalf("reset schedule state: %v", err)
+	}
+	if _, err := pool.Exec(ctx, `DELETE FROM gokart_jobs WHERE kind = $1`, kind); err != nil {
+		t.Fatalf("reset jobs: %v", err)
+	}
+
+	schedules := []CronSchedule{{Spec: "* * * * *", Kind: kind}}
+	cfg := CronConfig{Pool: pool}.withDefaults()
+	specs := parseCronSpecs(t, schedules)
+
+	if err := seedNextRun(ctx, pool, schedules, specs); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	// Force the schedule due, as if it were missed while no replica held
+	// the leader lock.
+	if _, err := pool.Exec(ctx,
+		`UPDATE gokart_cron_schedules SET next_run_at = $2 WHERE kind = $1`,
+		kind, time.Now().Add(-time.Hour),
+	); err != nil {
+		t.Fatalf("force due: %v", err)
+	}
+
+	// Two separate CronConfig values stand in for two replicas sharing the
+	// same Postgres pool; each ticks independently.
+	replicaA := cfg
+	replicaB := cfg
+
+	replicaA.runDueSchedules(ctx, schedules, specs)
+	replicaB.runDueSchedules(ctx, schedules, specs)
+
+	var count int
+	if err := pool.QueryRow(ctx, `SELECT count(*) FROM gokart_jobs WHERE kind = $1`, kind).Scan(&count); err != nil {
+		t.Fatalf("count jobs: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("expected exactly 1 enqueued job, got %d", count)
+	}
+
+	var nextRunAt time.Time
+	if err := pool.QueryRow(ctx, `SELECT next_run_at FROM gokart_cron_schedules WHERE kind = $1`, kind).Scan(&nextRunAt); err != nil {
+		t.Fatalf("read next_run_at: %v", err)
+	}
+	if !nextRunAt.After(time.Now()) {
+		t.Fatalf("expected next_run_at advanced into the future, got %v", nextRunAt)
+	}
+}
+
+// parseCronSpecs parses schedules the same way Cron does internally.
+func parseCronSpecs(t *testing.T, schedules []CronSchedule) []cron.Schedule {
+	t.Helper()
+	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+	specs := make([]cron.Schedule, len(schedules))
+	for i, sch := range schedules {
+		parsed, err := parser.Parse(sch.Spec)
+		if err != nil {
+			t.Fatalf("parse spec %q: %v", sch.Spec, err)
+		}
+		specs[i] = parsed
+	}
+	return specs
+}
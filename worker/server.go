@@ -0,0 +1,301 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// HandlerFunc processes one job's payload. A returned error marks the
+// job for retry (with exponential backoff) until MaxAttempts is
+// exhausted, at which point it's marked StatusFailed.
+type HandlerFunc func(ctx context.Context, payload json.RawMessage) error
+
+// ServerConfig configures a Server.
+type ServerConfig struct {
+	// Pool is the pgxpool jobs are read from and written back to.
+	Pool *pgxpool.Pool
+
+	// PollInterval is how often each registered kind is polled for new
+	// jobs. Default: 1s.
+	PollInterval time.Duration
+
+	// Lease is how long a job may sit in StatusRunning before the
+	// stuck-job reaper resets it back to StatusPending, assuming the
+	// worker that claimed it died mid-run. Default: 5m.
+	Lease time.Duration
+
+	// ReapInterval is how often the stuck-job reaper runs. Default: 30s.
+	ReapInterval time.Duration
+
+	// LockerName identifies this Server in locked_by, for diagnosing
+	// which process is holding a job. Default: hostname:pid.
+	LockerName string
+
+	// Logger receives claim/complete/fail/reap events. Default:
+	// slog.Default().
+	Logger *slog.Logger
+}
+
+func (c ServerConfig) withDefaults() ServerConfig {
+	if c.PollInterval == 0 {
+		c.PollInterval = time.Second
+	}
+	if c.Lease == 0 {
+		c.Lease = 5 * time.Minute
+	}
+	if c.ReapInterval == 0 {
+		c.ReapInterval = 30 * time.Second
+	}
+	if c.LockerName == "" {
+		host, _ := os.Hostname()
+		c.LockerName = fmt.Sprintf("%s:%d", host, os.Getpid())
+	}
+	if c.Logger == nil {
+		c.Logger = slog.Default()
+	}
+	return c
+}
+
+type handlerEntry struct {
+	fn          HandlerFunc
+	concurrency int
+	sem         chan struct{}
+}
+
+// Server polls every registered job kind on one shared ticker and runs
+// a stuck-job reaper, dispatching claimed jobs to their HandlerFunc with
+// a per-kind concurrency limit.
+//
+// Example:
+//
+//	s := worker.NewServer(worker.ServerConfig{Pool: pool})
+//	s.Register("send_welcome_email", 10, func(ctx context.Context, payload json.RawMessage) error {
+//	    var p EmailPayload
+//	    if err := json.Unmarshal(payload, &p); err != nil {
+//	        return err
+//	    }
+//	    return sendWelcomeEmail(ctx, p.UserID)
+//	})
+//	if err := s.Run(ctx); err != nil {
+//	    log.Fatal(err)
+//	}
+type Server struct {
+	cfg ServerConfig
+
+	mu       sync.Mutex
+	handlers map[string]*handlerEntry
+}
+
+// NewServer returns a Server ready for Register calls.
+func NewServer(cfg ServerConfig) *Server {
+	return &Server{cfg: cfg.withDefaults(), handlers: make(map[string]*handlerEntry)}
+}
+
+// Register installs fn as the handler for kind, run with up to
+// concurrency jobs of that kind in flight at once.
+func (s *Server) Register(kind string, concurrency int, fn HandlerFunc) {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[kind] = &handlerEntry{fn: fn, concurrency: concurrency, sem: make(chan struct{}, concurrency)}
+}
+
+// Run polls for jobs of every registered kind and runs the stuck-job
+// reaper until ctx is canceled, then waits for in-flight jobs to finish
+// before returning.
+func (s *Server) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.reapLoop(ctx)
+	}()
+
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return nil
+		case <-ticker.C:
+			s.pollOnce(ctx, &wg)
+		}
+	}
+}
+
+// pollOnce tries to claim and dispatch one job per registered kind that
+// still has a free concurrency slot.
+func (s *Server) pollOnce(ctx context.Context, wg *sync.WaitGroup) {
+	s.mu.Lock()
+	entries := make(map[string]*handlerEntry, len(s.handlers))
+	for kind, h := range s.handlers {
+		entries[kind] = h
+	}
+	s.mu.Unlock()
+
+	for kind, h := range entries {
+		select {
+		case h.sem <- struct{}{}:
+		default:
+			continue // at concurrency limit for this kind
+		}
+
+		wg.Add(1)
+		go func(kind string, h *handlerEntry) {
+			defer wg.Done()
+			defer func() { <-h.sem }()
+			s.dispatchOne(ctx, kind, h)
+		}(kind, h)
+	}
+}
+
+// dispatchOne claims and runs a single job of kind, if one is due. It is
+// a no-op if no job is available.
+func (s *Server) dispatchOne(ctx context.Context, kind string, h *handlerEntry) {
+	job, err := s.claim(ctx, kind)
+	if err != nil {
+		s.cfg.Logger.Error("worker: claim failed", "kind", kind, "error", err)
+		return
+	}
+	if job == nil {
+		return
+	}
+
+	if err := h.fn(ctx, job.Payload); err != nil {
+		s.fail(ctx, job, err)
+		return
+	}
+	s.complete(ctx, job)
+}
+
+// claim atomically selects and locks the oldest due pending job of kind,
+// using SELECT ... FOR UPDATE SKIP LOCKED so concurrent Servers never
+// claim the same row.
+func (s *Server) claim(ctx context.Context, kind string) (*Job, error) {
+	tx, err := s.cfg.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("worker: begin claim: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var job Job
+	err = tx.QueryRow(ctx, `
+		SELECT id, kind, payload, attempts, max_attempts
+		FROM gokart_jobs
+		WHERE kind = $1 AND status = $2 AND run_at <= now()
+		ORDER BY run_at
+		FOR UPDATE SKIP LOCKED
+		LIMIT 1
+	`, kind, StatusPending).Scan(&job.ID, &job.Kind, &job.Payload, &job.Attempts, &job.MaxAttempts)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("worker: claim %q: %w", kind, err)
+	}
+
+	if _, err := tx.Exec(ctx,
+		`UPDATE gokart_jobs SET status = $2, locked_at = now(), locked_by = $3 WHERE id = $1`,
+		job.ID, StatusRunning, s.cfg.LockerName,
+	); err != nil {
+		return nil, fmt.Errorf("worker: lock job %d: %w", job.ID, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("worker: commit claim of job %d: %w", job.ID, err)
+	}
+
+	s.cfg.Logger.Info("worker: claimed job", "id", job.ID, "kind", job.Kind)
+	return &job, nil
+}
+
+// complete marks job done.
+func (s *Server) complete(ctx context.Context, job *Job) {
+	_, err := s.cfg.Pool.Exec(ctx,
+		`UPDATE gokart_jobs SET status = $2, locked_at = NULL, locked_by = NULL WHERE id = $1`,
+		job.ID, StatusDone,
+	)
+	if err != nil {
+		s.cfg.Logger.Error("worker: mark job done failed", "id", job.ID, "error", err)
+		return
+	}
+	s.cfg.Logger.Info("worker: job done", "id", job.ID, "kind", job.Kind)
+}
+
+// fail records jobErr against job, rescheduling it with exponential
+// backoff until MaxAttempts is exhausted, at which point it's marked
+// StatusFailed.
+func (s *Server) fail(ctx context.Context, job *Job, jobErr error) {
+	attempts := job.Attempts + 1
+
+	if attempts >= job.MaxAttempts {
+		_, err := s.cfg.Pool.Exec(ctx,
+			`UPDATE gokart_jobs SET status = $2, attempts = $3, last_error = $4, locked_at = NULL, locked_by = NULL WHERE id = $1`,
+			job.ID, StatusFailed, attempts, jobErr.Error(),
+		)
+		if err != nil {
+			s.cfg.Logger.Error("worker: mark job failed failed", "id", job.ID, "error", err)
+			return
+		}
+		s.cfg.Logger.Error("worker: job exhausted retries", "id", job.ID, "kind", job.Kind, "attempts", attempts, "error", jobErr)
+		return
+	}
+
+	runAt := time.Now().Add(backoff(attempts))
+	_, err := s.cfg.Pool.Exec(ctx,
+		`UPDATE gokart_jobs SET status = $2, attempts = $3, run_at = $4, last_error = $5, locked_at = NULL, locked_by = NULL WHERE id = $1`,
+		job.ID, StatusPending, attempts, runAt, jobErr.Error(),
+	)
+	if err != nil {
+		s.cfg.Logger.Error("worker: reschedule job failed", "id", job.ID, "error", err)
+		return
+	}
+	s.cfg.Logger.Warn("worker: job failed, rescheduled", "id", job.ID, "kind", job.Kind, "attempts", attempts, "run_at", runAt, "error", jobErr)
+}
+
+// reapLoop runs reapStuck every ReapInterval until ctx is canceled.
+func (s *Server) reapLoop(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.ReapInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.reapStuck(ctx)
+		}
+	}
+}
+
+// reapStuck resets jobs that have been StatusRunning for longer than
+// Lease back to StatusPending, recovering from a worker that claimed a
+// job and died before completing it.
+func (s *Server) reapStuck(ctx context.Context) {
+	cutoff := time.Now().Add(-s.cfg.Lease)
+	tag, err := s.cfg.Pool.Exec(ctx, `
+		UPDATE gokart_jobs
+		SET status = $1, locked_at = NULL, locked_by = NULL
+		WHERE status = $2 AND locked_at < $3
+	`, StatusPending, StatusRunning, cutoff)
+	if err != nil {
+		s.cfg.Logger.Error("worker: reap stuck jobs failed", "error", err)
+		return
+	}
+	if tag.RowsAffected() > 0 {
+		s.cfg.Logger.Warn("worker: reaped stuck jobs", "count", tag.RowsAffected())
+	}
+}
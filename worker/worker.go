@@ -0,0 +1,123 @@
+// Package worker layers a Postgres-backed job queue on top of a
+// *pgxpool.Pool (as returned by postgres.Open), inspired by the scheduler/worker split common to
+// background-job systems: callers Enqueue work into a table, and one or
+// more Server instances pull it back out with SELECT ... FOR UPDATE
+// SKIP LOCKED so multiple processes can share the queue without
+// double-processing a row.
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Status is a job's place in its lifecycle.
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusRunning Status = "running"
+	StatusDone    Status = "done"
+	StatusFailed  Status = "failed"
+)
+
+// Job is one row of the gokart_jobs table, as handed to a HandlerFunc.
+type Job struct {
+	ID          int64
+	Kind        string
+	Payload     json.RawMessage
+	Attempts    int
+	MaxAttempts int
+}
+
+// schemaSQL creates the gokart_jobs table and its indices. Safe to run
+// repeatedly; every statement is idempotent.
+const schemaSQL = `
+CREATE TABLE IF NOT EXISTS gokart_jobs (
+	id           BIGSERIAL PRIMARY KEY,
+	kind         TEXT NOT NULL,
+	payload      JSONB NOT NULL DEFAULT '{}'::jsonb,
+	status       TEXT NOT NULL DEFAULT 'pending',
+	run_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+	attempts     INT NOT NULL DEFAULT 0,
+	max_attempts INT NOT NULL DEFAULT 5,
+	locked_at    TIMESTAMPTZ,
+	locked_by    TEXT,
+	last_error   TEXT,
+	created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS gokart_jobs_pending_run_at_idx
+	ON gokart_jobs (kind, run_at) WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS gokart_jobs_locked_at_idx
+	ON gokart_jobs (locked_at) WHERE status = 'running';
+`
+
+// Migrate creates the gokart_jobs table and its indices if they don't
+// already exist. Call it once at startup before Enqueue or Server.Run.
+func Migrate(ctx context.Context, pool *pgxpool.Pool) error {
+	if _, err := pool.Exec(ctx, schemaSQL); err != nil {
+		return fmt.Errorf("worker: migrate: %w", err)
+	}
+	return nil
+}
+
+// EnqueueOptions configures Enqueue.
+type EnqueueOptions struct {
+	// RunAt delays the job until this time. Default: now.
+	RunAt time.Time
+
+	// MaxAttempts caps how many times the job is attempted in total
+	// before it's marked StatusFailed. Default: 5.
+	MaxAttempts int
+}
+
+func (o EnqueueOptions) withDefaults() EnqueueOptions {
+	if o.RunAt.IsZero() {
+		o.RunAt = time.Now()
+	}
+	if o.MaxAttempts == 0 {
+		o.MaxAttempts = 5
+	}
+	return o
+}
+
+// Enqueue writes a new pending job of kind to gokart_jobs, marshaling
+// payload to JSON, and returns its id.
+//
+// Example:
+//
+//	id, err := worker.Enqueue(ctx, pool, "send_welcome_email", EmailPayload{UserID: 42}, worker.EnqueueOptions{})
+func Enqueue(ctx context.Context, pool *pgxpool.Pool, kind string, payload any, opts EnqueueOptions) (int64, error) {
+	opts = opts.withDefaults()
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return 0, fmt.Errorf("worker: marshal %q payload: %w", kind, err)
+	}
+
+	var id int64
+	err = pool.QueryRow(ctx,
+		`INSERT INTO gokart_jobs (kind, payload, run_at, max_attempts) VALUES ($1, $2, $3, $4) RETURNING id`,
+		kind, data, opts.RunAt, opts.MaxAttempts,
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("worker: enqueue %q: %w", kind, err)
+	}
+	return id, nil
+}
+
+// backoff returns the delay before retrying a job that has failed
+// attempt times, doubling each attempt and capping at 5 minutes.
+func backoff(attempt int) time.Duration {
+	d := time.Duration(1<<uint(attempt)) * time.Second
+	if d > 5*time.Minute {
+		d = 5 * time.Minute
+	}
+	return d
+}